@@ -0,0 +1,75 @@
+// Command terrakube-export generates Terraform configuration for an existing
+// Terrakube organization. It writes the resources, the import blocks that
+// bring them under management and input variables for the sensitive values
+// the API doesn't return.
+//
+//	terrakube-export -organization simple -out ./simple
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"terraform-provider-terrakube/internal/export"
+)
+
+func main() {
+	var endpoint, token, organization, out string
+	var insecure bool
+
+	flag.StringVar(&endpoint, "endpoint", os.Getenv("TERRAKUBE_ENDPOINT"), "Terrakube API endpoint, defaults to TERRAKUBE_ENDPOINT")
+	flag.StringVar(&token, "token", os.Getenv("TERRAKUBE_TOKEN"), "Terrakube personal access token, defaults to TERRAKUBE_TOKEN")
+	flag.StringVar(&organization, "organization", "", "name of the organization to export")
+	flag.StringVar(&out, "out", ".", "directory the .tf files are written to")
+	flag.BoolVar(&insecure, "insecure", false, "skip TLS certificate verification")
+	flag.Parse()
+
+	if endpoint == "" || token == "" || organization == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	httpClient := &http.Client{}
+	if insecure {
+		if custom, ok := http.DefaultTransport.(*http.Transport); ok {
+			customTransport := custom.Clone()
+			customTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+			httpClient = &http.Client{Transport: customTransport}
+		}
+	}
+
+	exporter := export.Exporter{
+		Client:   httpClient,
+		Endpoint: strings.TrimSuffix(endpoint, "/"),
+		Token:    token,
+	}
+	result, err := exporter.Export(context.Background(), organization)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.MkdirAll(out, 0o755); err != nil {
+		log.Fatal(err)
+	}
+
+	files := result.Files()
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(out, name), files[name], 0o644); err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("wrote %s", filepath.Join(out, name))
+	}
+	log.Printf("exported %d resources of organization %s", result.Resources, organization)
+}
@@ -0,0 +1,12 @@
+data "terrakube_effective_notifications" "networking_failures" {
+  organization_id = data.terrakube_organization.org.id
+  workspace_id    = data.terrakube_workspace.networking.id
+  job_status      = "failed"
+}
+
+output "paged_on_failure" {
+  value = [
+    for notification in data.terrakube_effective_notifications.networking_failures.notifications :
+    "${notification.scope} ${notification.name} (${notification.channel_type})"
+  ]
+}
@@ -0,0 +1,16 @@
+ephemeral "terrakube_output" "database" {
+  workspace    = "database"
+  organization = "orgname"
+}
+
+resource "terrakube_workspace_variable" "db_password" {
+  organization_id  = data.terrakube_organization.org.id
+  workspace_id     = terrakube_workspace_cli.app.id
+  key              = "db_password"
+  value_wo         = ephemeral.terrakube_output.database.values.password
+  value_wo_version = 1
+  description      = "Database password read from the database workspace"
+  category         = "TERRAFORM"
+  sensitive        = true
+  hcl              = false
+}
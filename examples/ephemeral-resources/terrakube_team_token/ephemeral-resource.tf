@@ -0,0 +1,16 @@
+ephemeral "terrakube_team_token" "ci" {
+  team_name   = "AZURE_DEVELOPERS"
+  description = "token for the deployment pipeline"
+  minutes     = 30
+}
+
+resource "kubernetes_secret_v1" "terrakube" {
+  metadata {
+    name = "terrakube-token"
+  }
+
+  data_wo = {
+    token = ephemeral.terrakube_team_token.ci.value
+  }
+  data_wo_revision = 1
+}
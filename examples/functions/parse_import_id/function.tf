@@ -0,0 +1,7 @@
+locals {
+  variable = provider::terrakube::parse_import_id("00000000-0000-0000-0000-000000000000,11111111-1111-1111-1111-111111111111,22222222-2222-2222-2222-222222222222")
+}
+
+output "workspace_id" {
+  value = local.variable.parent_ids[0]
+}
@@ -0,0 +1,3 @@
+output "next_runs" {
+  value = provider::terrakube::quartz_next("0 0 12 ? * MON-FRI", 3, plantimestamp())
+}
@@ -0,0 +1,3 @@
+output "token_expiration" {
+  value = provider::terrakube::token_claims(var.team_token)["exp"]
+}
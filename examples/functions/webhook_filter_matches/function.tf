@@ -0,0 +1,3 @@
+output "triggering_files" {
+  value = provider::terrakube::webhook_filter_matches(["modules/.*\\.tf"], ["modules/vpc/main.tf", "README.md"])
+}
@@ -0,0 +1,4 @@
+import {
+  to = terrakube_workspace_variable.region
+  id = provider::terrakube::workspace_import_id(var.organization_id, var.workspace_id, var.variable_id)
+}
@@ -0,0 +1,7 @@
+list "terrakube_team" "all" {
+  provider = terrakube
+
+  config {
+    organization_id = "00000000-0000-0000-0000-000000000000"
+  }
+}
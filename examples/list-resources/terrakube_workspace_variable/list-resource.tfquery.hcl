@@ -0,0 +1,8 @@
+list "terrakube_workspace_variable" "all" {
+  provider = terrakube
+
+  config {
+    organization_id = "00000000-0000-0000-0000-000000000000"
+    workspace_id    = "00000000-0000-0000-0000-000000000000"
+  }
+}
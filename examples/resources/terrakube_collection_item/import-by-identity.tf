@@ -0,0 +1,8 @@
+import {
+  to       = terrakube_collection_item.example
+  identity = {
+    organization_id = "00000000-0000-0000-0000-000000000000"
+    collection_id   = "00000000-0000-0000-0000-000000000000"
+    id              = "00000000-0000-0000-0000-000000000000"
+  }
+}
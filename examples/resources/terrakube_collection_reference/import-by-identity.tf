@@ -0,0 +1,9 @@
+import {
+  to       = terrakube_collection_reference.example
+  identity = {
+    organization_id = "00000000-0000-0000-0000-000000000000"
+    collection_id   = "00000000-0000-0000-0000-000000000000"
+    workspace_id    = "00000000-0000-0000-0000-000000000000"
+    id              = "00000000-0000-0000-0000-000000000000"
+  }
+}
@@ -1,2 +1,5 @@
-# Organization Workspace Variable can be import with organization_id,collection_id,workspace_id,id
-terraform import terrakube_workspace_variable.example 00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000,,00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000
\ No newline at end of file
+# Collection Reference can be import with organization_id,collection_id,workspace_id,id
+terraform import terrakube_collection_reference.example 00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000
+
+# It can also be imported with organization_name/collection_name/workspace_name
+terraform import terrakube_collection_reference.example acme/shared/network
@@ -1,2 +1,5 @@
 # Federated credential can be imported with id
 terraform import terrakube_federated_credential.example 00000000-0000-0000-0000-000000000000
+
+# It can also be imported with federated_credential_name
+terraform import terrakube_federated_credential.example github-actions
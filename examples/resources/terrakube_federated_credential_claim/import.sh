@@ -1,2 +1,5 @@
 # Federated credential claim can be imported with federated_credential_id,id
 terraform import terrakube_federated_credential_claim.example 00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000
+
+# It can also be imported with federated_credential_name/claim_key
+terraform import terrakube_federated_credential_claim.example github-actions/repository
@@ -1,2 +1,5 @@
 # Collection can be import with organization_id,id
-terraform import terrakube_collection.example 00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000
\ No newline at end of file
+terraform import terrakube_collection.example 00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000
+
+# It can also be imported with organization_name/collection_name
+terraform import terrakube_collection.example acme/defaults
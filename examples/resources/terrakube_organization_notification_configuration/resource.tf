@@ -9,3 +9,17 @@ resource "terrakube_organization_notification_configuration" "prod_alerts" {
   trigger_statuses = ["failed", "rejected", "cancelled"]
   # Empty/omitted template_ids (the default) means this applies to every template.
 }
+
+resource "terrakube_organization_notification_configuration" "incidents" {
+  organization_id  = data.terrakube_organization.org.id
+  name             = "incidents"
+  channel_type     = "WEBHOOK"
+  destination_url  = "https://incidents.example.com/api/events"
+  trigger_statuses = ["failed"]
+  body_template    = jsonencode({ summary = "{{workspace}} job {{jobId}} {{jobStatus}}", link = "{{url}}" })
+  headers = {
+    Authorization = "Bearer ${var.incident_token}"
+  }
+  # Fail the apply when the incident tool rejects a test notification.
+  send_test_on_change = true
+}
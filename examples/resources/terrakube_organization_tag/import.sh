@@ -1,2 +1,5 @@
 # Organization Tag can be import with organization_id,id
-terraform import terrakube_organization_tag.example 00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000
\ No newline at end of file
+terraform import terrakube_organization_tag.example 00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000
+
+# It can also be imported with organization_name/tag_name
+terraform import terrakube_organization_tag.example acme/production
@@ -1,2 +1,5 @@
 # Organization Template can be import with organization_id,id
-terraform import terrakube_organization_template.example 00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000
\ No newline at end of file
+terraform import terrakube_organization_template.example 00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000
+
+# It can also be imported with organization_name/template_name
+terraform import terrakube_organization_template.example acme/plan-and-apply
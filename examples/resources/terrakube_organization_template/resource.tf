@@ -9,4 +9,37 @@ flow:
     name: "Plan"
     step: 100
   EOF
-}
\ No newline at end of file
+}
+
+resource "terrakube_organization_template" "flow" {
+  name            = "plan-and-apply"
+  organization_id = terrakube_organization.example.id
+  description     = "Template written with flow blocks"
+  version         = "1.0.0"
+
+  flow {
+    type = "terraformPlan"
+    name = "Plan"
+    step = 100
+
+    command {
+      runtime  = "BASH"
+      priority = 100
+      after    = true
+      script   = "echo \"plan finished\""
+    }
+  }
+
+  flow {
+    type = "approval"
+    name = "Approve"
+    step = 150
+    team = "TERRAFORM_ADVANCED"
+  }
+
+  flow {
+    type = "terraformApply"
+    name = "Apply"
+    step = 200
+  }
+}
@@ -1,2 +1,5 @@
 # Project can be imported with organization_id,id
-terraform import terrakube_project.example 00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000
\ No newline at end of file
+terraform import terrakube_project.example 00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000
+
+# It can also be imported with organization_name/project_name
+terraform import terrakube_project.example acme/platform
@@ -1,2 +1,5 @@
 # Project access can be imported with organization_id,project_id,id
-terraform import terrakube_project_access.example 00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000
\ No newline at end of file
+terraform import terrakube_project_access.example 00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000
+
+# It can also be imported with organization_name/project_name/team_name
+terraform import terrakube_project_access.example acme/platform/OPS
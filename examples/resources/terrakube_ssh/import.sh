@@ -1,2 +1,5 @@
 # Team can be import with organization_id,id
 terraform import terrakube_ssh.example 00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000
+
+# It can also be imported with organization_name/ssh_key_name
+terraform import terrakube_ssh.example acme/deploy-key
@@ -0,0 +1,7 @@
+import {
+  to       = terrakube_team.example
+  identity = {
+    organization_id = "00000000-0000-0000-0000-000000000000"
+    id              = "00000000-0000-0000-0000-000000000000"
+  }
+}
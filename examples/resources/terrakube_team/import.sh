@@ -1,2 +1,5 @@
 # Team can be imported with organization_id,id
-terraform import terrakube_team.example 00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000
\ No newline at end of file
+terraform import terrakube_team.example 00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000
+
+# It can also be imported with organization_name/team_name
+terraform import terrakube_team.example acme/OPS
@@ -1,2 +1,5 @@
-# Organization Template can be import with id
-terraform import terrakube_team_token.example 00000000-0000-0000-0000-000000000000
\ No newline at end of file
+# Team Token can be import with id
+terraform import terrakube_team_token.example 00000000-0000-0000-0000-000000000000
+
+# It can also be imported with team_name/token_description
+terraform import terrakube_team_token.example DEVELOPERS/ci
@@ -4,4 +4,17 @@ resource "terrakube_team_token" "token1" {
   days        = 1
   hours       = 1
   minutes     = 1
-}
\ No newline at end of file
+}
+
+resource "terrakube_team_token" "rotating" {
+  description   = "rotated pipeline token"
+  team_name     = "AZURE_DEVELOPERS"
+  days          = 30
+  hours         = 0
+  minutes       = 0
+  rotate_before = "72h"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
@@ -8,3 +8,13 @@ resource "terrakube_vcs" "vcs" {
   endpoint        = "https://github.com"
   api_url         = "https://api.github.com"
 }
+
+resource "terrakube_vcs" "github_app" {
+  organization_id = data.terrakube_organization.org.id
+  name            = "GithubApp"
+  description     = "GitHub App connection"
+  vcs_type        = "GITHUB"
+  connection_type = "STANDALONE"
+  client_id       = "123456"
+  private_key     = file("github-app.private-key.pem")
+}
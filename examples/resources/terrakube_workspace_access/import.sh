@@ -1,2 +1,5 @@
 # Workspace access can be imported with organization_id,workspace_id,id
 terraform import terrakube_workspace_access.example 00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000
+
+# It can also be imported with organization_name/workspace_name/team_name
+terraform import terrakube_workspace_access.example acme/network/OPS
@@ -0,0 +1,5 @@
+# Workspace Schedule can be import with workspace_id,id
+terraform import terrakube_workspace_schedule.example 00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000
+
+# It can also be imported with organization_name/workspace_name/template_name
+terraform import terrakube_workspace_schedule.example acme/network/nightly-plan
@@ -0,0 +1,12 @@
+resource "terrakube_workspace_schedule" "drift_detection" {
+  workspace_id = terrakube_workspace_cli.sample1.id
+  template_id  = terrakube_organization_template.drift.id
+  schedule     = "0 0 3 ? * MON-FRI"
+  timezone     = "Europe/Madrid"
+
+  # Resume the nightly runs once the end of year freeze is over.
+  enabled = true
+  window = {
+    start = "2026-01-07T00:00:00+01:00"
+  }
+}
@@ -0,0 +1,5 @@
+# Workspace Tag can be import with organization_id,workspace_id,id
+terraform import terrakube_workspace_tag.example 00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000
+
+# It can also be imported with organization_name/workspace_name/tag_name
+terraform import terrakube_workspace_tag.example acme/network/production
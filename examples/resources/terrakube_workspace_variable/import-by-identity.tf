@@ -0,0 +1,8 @@
+import {
+  to       = terrakube_workspace_variable.example
+  identity = {
+    organization_id = "00000000-0000-0000-0000-000000000000"
+    workspace_id    = "00000000-0000-0000-0000-000000000000"
+    id              = "00000000-0000-0000-0000-000000000000"
+  }
+}
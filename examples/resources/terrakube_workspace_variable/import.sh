@@ -1,2 +1,5 @@
 # Organization Workspace Variable can be import with organization_id,workspace_id,id
-terraform import terrakube_workspace_variable.example 00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000
\ No newline at end of file
+terraform import terrakube_workspace_variable.example 00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000
+
+# It can also be imported with organization_name/workspace_name/variable_key
+terraform import terrakube_workspace_variable.example acme/network/region
@@ -18,4 +18,16 @@ resource "terrakube_workspace_variable" "sample2" {
   category        = "TERRAFORM"
   sensitive       = false
   hcl             = false
-}
\ No newline at end of file
+}
+
+resource "terrakube_workspace_variable" "sample3" {
+  organization_id  = data.terrakube_organization.org.id
+  workspace_id     = terrakube_workspace_cli.sample1.id
+  key              = "sample-secret-var"
+  value_wo         = var.sample_secret
+  value_wo_version = 1
+  description      = "sample write-only secret, never stored in state"
+  category         = "ENV"
+  sensitive        = true
+  hcl              = false
+}
@@ -1,2 +1,5 @@
 # Webhook can be import with organization_id,workspace_id,id
-terraform import terrakube_workspace_webhook.example 00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000
\ No newline at end of file
+terraform import terrakube_workspace_webhook.example 00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000
+
+# It can also be imported with organization_name/workspace_name
+terraform import terrakube_workspace_webhook.example acme/network
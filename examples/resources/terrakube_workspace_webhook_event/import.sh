@@ -0,0 +1,5 @@
+# Workspace Webhook Event can be import with webhook_id,id
+terraform import terrakube_workspace_webhook_event.example 00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000
+
+# It can also be imported with organization_name/workspace_name/event_priority
+terraform import terrakube_workspace_webhook_event.example acme/network/1
@@ -0,0 +1,33 @@
+data "terrakube_organization" "org" {
+  name = "simple"
+}
+
+data "terrakube_workspace" "workspace" {
+  name            = "workspace1"
+  organization_id = data.terrakube_organization.org.id
+}
+
+data "terrakube_organization_template" "template" {
+  name            = "Terraform-Plan/Apply"
+  organization_id = data.terrakube_organization.org.id
+}
+
+resource "terrakube_workspace_webhook_v2" "webhook" {
+  organization_id = data.terrakube_organization.org.id
+  workspace_id    = data.terrakube_workspace.workspace.id
+  migrated_v2     = true
+
+  event {
+    event       = "PUSH"
+    branch      = ["main"]
+    path        = ["modules/.*\\.tf"]
+    template_id = data.terrakube_organization_template.template.id
+  }
+
+  event {
+    event               = "PULL_REQUEST"
+    branch              = ["feature/.*"]
+    template_id         = data.terrakube_organization_template.template.id
+    pr_workflow_enabled = true
+  }
+}
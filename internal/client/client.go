@@ -90,6 +90,7 @@ type WorkspaceVariableEntity struct {
 	Category    string `jsonapi:"attr,category"`
 	Sensitive   bool   `jsonapi:"attr,sensitive"`
 	Hcl         bool   `jsonapi:"attr,hcl"`
+	UpdatedDate string `jsonapi:"attr,updatedDate,omitempty"`
 }
 
 type WorkspaceAccessEntity struct {
@@ -128,6 +129,7 @@ type OrganizationVariableEntity struct {
 	Category    string `jsonapi:"attr,category"`
 	Sensitive   *bool  `jsonapi:"attr,sensitive,omitempty"`
 	Hcl         bool   `jsonapi:"attr,hcl"`
+	UpdatedDate string `jsonapi:"attr,updatedDate,omitempty"`
 }
 
 type VcsEntity struct {
@@ -186,6 +188,7 @@ type CollectionItemEntity struct {
 	Category    string  `jsonapi:"attr,category"`
 	Sensitive   bool    `jsonapi:"attr,sensitive"`
 	Hcl         bool    `jsonapi:"attr,hcl"`
+	UpdatedDate string  `jsonapi:"attr,updatedDate,omitempty"`
 }
 
 type CollectionReferenceEntity struct {
@@ -252,9 +255,13 @@ type WorkspaceWebhookEventEntity struct {
 }
 
 type WorkspaceScheduleEntity struct {
-	ID         string `jsonapi:"primary,schedule"`
-	Schedule   string `jsonapi:"attr,cron"`
-	TemplateId string `jsonapi:"attr,templateReference"`
+	ID         string  `jsonapi:"primary,schedule"`
+	Schedule   string  `jsonapi:"attr,cron"`
+	TemplateId string  `jsonapi:"attr,templateReference"`
+	Enabled    *bool   `jsonapi:"attr,enabled,omitempty"`
+	Timezone   string  `jsonapi:"attr,timezone,omitempty"`
+	StartDate  *string `jsonapi:"attr,startDate,omitempty"`
+	EndDate    *string `jsonapi:"attr,endDate,omitempty"`
 }
 
 type FederatedEntity struct {
@@ -271,15 +278,18 @@ type FederatedClaimEntity struct {
 }
 
 type NotificationConfigurationEntity struct {
-	ID             string           `jsonapi:"primary,notification_configuration"`
-	Name           string           `jsonapi:"attr,name"`
-	Description    *string          `jsonapi:"attr,description,omitempty"`
-	ChannelType    string           `jsonapi:"attr,channelType"`
-	DestinationUrl string           `jsonapi:"attr,destinationUrl"`
-	SigningSecret  *string          `jsonapi:"attr,signingSecret,omitempty"`
-	Active         bool             `jsonapi:"attr,active"`
-	MessageStyle   string           `jsonapi:"attr,messageStyle"`
-	Workspace      *WorkspaceEntity `jsonapi:"relation,workspace,omitempty"`
+	ID             string                 `jsonapi:"primary,notification_configuration"`
+	Name           string                 `jsonapi:"attr,name"`
+	Description    *string                `jsonapi:"attr,description,omitempty"`
+	ChannelType    string                 `jsonapi:"attr,channelType"`
+	DestinationUrl string                 `jsonapi:"attr,destinationUrl"`
+	SigningSecret  *string                `jsonapi:"attr,signingSecret,omitempty"`
+	Active         bool                   `jsonapi:"attr,active"`
+	MessageStyle   string                 `jsonapi:"attr,messageStyle"`
+	BodyTemplate   *string                `jsonapi:"attr,bodyTemplate,omitempty"`
+	Headers        map[string]interface{} `jsonapi:"attr,headers,omitempty"`
+	RoutingKey     *string                `jsonapi:"attr,routingKey,omitempty"`
+	Workspace      *WorkspaceEntity       `jsonapi:"relation,workspace,omitempty"`
 }
 
 type NotificationTriggerEntity struct {
@@ -54,6 +54,39 @@ func TestNotificationConfigurationEntity_MarshalUnmarshalRoundTrip(t *testing.T)
 	}
 }
 
+func TestNotificationConfigurationEntity_TemplatedWebhookRoundTrip(t *testing.T) {
+	bodyTemplate := `{"summary":"{{workspace}} is {{jobStatus}}"}`
+	original := &NotificationConfigurationEntity{
+		Name:           "incidents",
+		ChannelType:    "WEBHOOK",
+		DestinationUrl: "https://incidents.example.com/hook",
+		Active:         true,
+		MessageStyle:   "DETAILED",
+		BodyTemplate:   &bodyTemplate,
+		Headers:        map[string]interface{}{"Authorization": "Bearer token"},
+	}
+
+	var out bytes.Buffer
+	if err := jsonapi.MarshalPayload(&out, original); err != nil {
+		t.Fatalf("MarshalPayload: %v", err)
+	}
+	if strings.Contains(out.String(), "routingKey") {
+		t.Errorf("expected an unset routingKey to be omitted, got: %s", out.String())
+	}
+
+	roundTripped := &NotificationConfigurationEntity{}
+	if err := jsonapi.UnmarshalPayload(strings.NewReader(out.String()), roundTripped); err != nil {
+		t.Fatalf("UnmarshalPayload: %v", err)
+	}
+
+	if roundTripped.BodyTemplate == nil || *roundTripped.BodyTemplate != bodyTemplate {
+		t.Errorf("BodyTemplate = %v, want %q", roundTripped.BodyTemplate, bodyTemplate)
+	}
+	if roundTripped.Headers["Authorization"] != "Bearer token" {
+		t.Errorf("Headers = %v, want the Authorization header", roundTripped.Headers)
+	}
+}
+
 func TestNotificationTriggerEntity_MarshalUnmarshalRoundTrip(t *testing.T) {
 	original := &NotificationTriggerEntity{JobStatus: "failed"}
 
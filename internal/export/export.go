@@ -0,0 +1,492 @@
+// Package export generates Terraform configuration, with import blocks, for
+// the resources of an existing Terrakube organization.
+package export
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"terraform-provider-terrakube/internal/client"
+
+	"github.com/google/jsonapi"
+)
+
+// Exporter reads an organization through the same API paths the provider
+// resources use.
+type Exporter struct {
+	Client   *http.Client
+	Endpoint string
+	Token    string
+}
+
+// Result holds the generated files. Variables is empty when the organization
+// has no sensitive values.
+type Result struct {
+	Main      *File
+	Imports   *File
+	Variables *File
+	Resources int
+}
+
+// Files returns the generated files by file name.
+func (r *Result) Files() map[string][]byte {
+	files := map[string][]byte{
+		"main.tf":    r.Main.Bytes(),
+		"imports.tf": r.Imports.Bytes(),
+	}
+	if len(r.Variables.Blocks) > 0 {
+		files["variables.tf"] = r.Variables.Bytes()
+	}
+	return files
+}
+
+type generator struct {
+	Exporter
+	ctx context.Context
+
+	organizationID   string
+	organizationName string
+	result           *Result
+	labels           map[string]map[string]bool
+	addresses        map[string]string
+}
+
+// Export generates the configuration of the organization with the given name.
+func (e Exporter) Export(ctx context.Context, organizationName string) (*Result, error) {
+	g := &generator{
+		Exporter:         e,
+		ctx:              ctx,
+		organizationName: organizationName,
+		result:           &Result{Main: &File{}, Imports: &File{}, Variables: &File{}},
+		labels:           map[string]map[string]bool{},
+		addresses:        map[string]string{},
+	}
+
+	providers := g.result.Main.Add("terraform")
+	providers.Blocks = append(providers.Blocks, &Block{
+		Type: "required_providers",
+		Attributes: []Attribute{{Name: "terrakube", Value: Object{
+			{Name: "source", Value: String("terrakube-io/terrakube")},
+		}}},
+	})
+
+	steps := []func() error{
+		g.organization,
+		g.teams,
+		g.tags,
+		g.templates,
+		g.vcs,
+		g.ssh,
+		g.projects,
+		g.organizationVariables,
+		g.collections,
+		g.modules,
+		g.workspaces,
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return nil, err
+		}
+	}
+	return g.result, nil
+}
+
+func (g *generator) organization() error {
+	var organizations []*client.OrganizationEntity
+	filter := url.QueryEscape(fmt.Sprintf("name=='%s'", strings.ReplaceAll(g.organizationName, "'", `\'`)))
+	if err := g.list("organization?filter[organization]="+filter, &organizations); err != nil {
+		return err
+	}
+	if len(organizations) != 1 {
+		return fmt.Errorf("organization %q not found", g.organizationName)
+	}
+
+	organization := organizations[0]
+	g.organizationID = organization.ID
+	g.resource("terrakube_organization", organization.Name, organization.ID, organization.ID).
+		Set("name", String(organization.Name)).
+		Set("description", optional(organization.Description)).
+		Set("execution_mode", String(organization.ExecutionMode)).
+		Set("icon", optional(organization.Icon))
+	return nil
+}
+
+func (g *generator) teams() error {
+	var teams []*client.TeamEntity
+	if err := g.list(g.organizationPath("team"), &teams); err != nil {
+		return err
+	}
+
+	for _, team := range teams {
+		block := g.resource("terrakube_team", team.Name, team.ID, g.importID(team.ID)).
+			Set("name", String(team.Name)).
+			Set("organization_id", g.reference(g.organizationID))
+		if team.Role != nil && *team.Role != "" {
+			block.Set("role", String(*team.Role))
+			continue
+		}
+		block.Set("manage_state", Bool(team.ManageState)).
+			Set("manage_workspace", Bool(team.ManageWorkspace)).
+			Set("manage_module", Bool(team.ManageModule)).
+			Set("manage_provider", Bool(team.ManageProvider)).
+			Set("manage_vcs", Bool(team.ManageVcs)).
+			Set("manage_template", Bool(team.ManageTemplate)).
+			Set("manage_job", Bool(team.ManageJob)).
+			Set("manage_collection", Bool(team.ManageCollection)).
+			Set("plan_job", Bool(team.PlanJob)).
+			Set("approve_job", Bool(team.ApproveJob))
+	}
+	return nil
+}
+
+func (g *generator) tags() error {
+	var tags []*client.OrganizationTagEntity
+	if err := g.list(g.organizationPath("tag"), &tags); err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		g.resource("terrakube_organization_tag", tag.Name, tag.ID, g.importID(tag.ID)).
+			Set("name", String(tag.Name)).
+			Set("organization_id", g.reference(g.organizationID))
+	}
+	return nil
+}
+
+func (g *generator) templates() error {
+	var templates []*client.OrganizationTemplateEntity
+	if err := g.list(g.organizationPath("template"), &templates); err != nil {
+		return err
+	}
+
+	for _, template := range templates {
+		// The API stores the content base64 encoded, the resource takes it decoded.
+		content, err := base64.StdEncoding.DecodeString(template.Content)
+		if err != nil {
+			return fmt.Errorf("unable to decode the content of template %q: %w", template.Name, err)
+		}
+		g.resource("terrakube_organization_template", template.Name, template.ID, g.importID(template.ID)).
+			Set("name", String(template.Name)).
+			Set("organization_id", g.reference(g.organizationID)).
+			Set("description", optional(template.Description)).
+			Set("version", optional(template.Version)).
+			Set("content", String(content))
+	}
+	return nil
+}
+
+func (g *generator) vcs() error {
+	var connections []*client.VcsEntity
+	if err := g.list(g.organizationPath("vcs"), &connections); err != nil {
+		return err
+	}
+
+	for _, vcs := range connections {
+		block := g.resource("terrakube_vcs", vcs.Name, vcs.ID, g.importID(vcs.ID))
+		label := block.Labels[1]
+		block.Set("name", String(vcs.Name)).
+			Set("organization_id", g.reference(g.organizationID)).
+			Set("description", String(vcs.Description)).
+			Set("vcs_type", String(vcs.VcsType)).
+			Set("connection_type", String(vcs.ConnectionType)).
+			Set("client_id", String(vcs.ClientId))
+		// STANDALONE connections authenticate as a GitHub App with its private
+		// key, OAUTH connections with the client secret.
+		if vcs.ConnectionType == "STANDALONE" {
+			block.Set("private_key", g.variable("vcs_"+label+"_private_key", "Private key of the "+vcs.Name+" VCS connection."))
+		} else {
+			block.Set("client_secret", g.variable("vcs_"+label+"_client_secret", "Client secret of the "+vcs.Name+" VCS connection."))
+		}
+		block.Set("endpoint", String(vcs.Endpoint)).
+			Set("api_url", String(vcs.ApiUrl))
+	}
+	return nil
+}
+
+func (g *generator) ssh() error {
+	var keys []*client.SshEntity
+	if err := g.list(g.organizationPath("ssh"), &keys); err != nil {
+		return err
+	}
+
+	for _, ssh := range keys {
+		block := g.resource("terrakube_ssh", ssh.Name, ssh.ID, g.importID(ssh.ID))
+		block.Set("name", String(ssh.Name)).
+			Set("organization_id", g.reference(g.organizationID)).
+			Set("description", optional(ssh.Description)).
+			Set("private_key", g.variable("ssh_"+block.Labels[1]+"_private_key", "Private key of the "+ssh.Name+" SSH key.")).
+			Set("ssh_type", String(ssh.SshType))
+	}
+	return nil
+}
+
+func (g *generator) projects() error {
+	var projects []*client.ProjectEntity
+	if err := g.list(g.organizationPath("project"), &projects); err != nil {
+		return err
+	}
+
+	for _, project := range projects {
+		g.resource("terrakube_project", project.Name, project.ID, g.importID(project.ID)).
+			Set("name", String(project.Name)).
+			Set("organization_id", g.reference(g.organizationID)).
+			Set("description", optional(project.Description))
+	}
+	return nil
+}
+
+func (g *generator) organizationVariables() error {
+	var variables []*client.OrganizationVariableEntity
+	if err := g.list(g.organizationPath("globalvar"), &variables); err != nil {
+		return err
+	}
+
+	for _, variable := range variables {
+		sensitive := variable.Sensitive != nil && *variable.Sensitive
+		block := g.resource("terrakube_organization_variable", variable.Key, variable.ID, g.importID(variable.ID))
+		block.Set("organization_id", g.reference(g.organizationID))
+		g.variableAttributes(block, "organization_variable_"+block.Labels[1], variable.Key, variable.Value, &variable.Description, variable.Category, sensitive, variable.Hcl)
+	}
+	return nil
+}
+
+func (g *generator) collections() error {
+	var collections []*client.CollectionEntity
+	if err := g.list(g.organizationPath("collection"), &collections); err != nil {
+		return err
+	}
+
+	for _, collection := range collections {
+		g.resource("terrakube_collection", collection.Name, collection.ID, g.importID(collection.ID)).
+			Set("name", String(collection.Name)).
+			Set("organization_id", g.reference(g.organizationID)).
+			Set("description", optional(collection.Description)).
+			Set("priority", Number(collection.Priority))
+
+		var items []*client.CollectionItemEntity
+		if err := g.list(g.organizationPath("collection/"+collection.ID+"/item"), &items); err != nil {
+			return err
+		}
+		for _, item := range items {
+			block := g.resource("terrakube_collection_item", collection.Name+"_"+item.Key, item.ID, g.importID(collection.ID, item.ID))
+			block.Set("organization_id", g.reference(g.organizationID)).
+				Set("collection_id", g.reference(collection.ID))
+			g.variableAttributes(block, "collection_item_"+block.Labels[1], item.Key, item.Value, item.Description, item.Category, item.Sensitive, item.Hcl)
+		}
+	}
+	return nil
+}
+
+func (g *generator) modules() error {
+	var modules []*client.ModuleEntity
+	if err := g.list(g.organizationPath("module"), &modules); err != nil {
+		return err
+	}
+
+	for _, module := range modules {
+		// Modules are imported by id alone, without the organization, so the
+		// names form of the import identifier is used instead.
+		importID := strings.Join([]string{g.organizationName, module.Name, module.Provider}, "/")
+		block := g.resource("terrakube_module", module.Name+"_"+module.Provider, module.ID, importID).
+			Set("name", String(module.Name)).
+			Set("organization_id", g.reference(g.organizationID)).
+			Set("description", String(module.Description)).
+			Set("provider_name", String(module.Provider)).
+			Set("source", String(module.Source))
+		if module.Vcs != nil && module.Vcs.ID != "" {
+			block.Set("vcs_id", g.reference(module.Vcs.ID))
+		}
+		if module.Ssh != nil && module.Ssh.ID != "" {
+			block.Set("ssh_id", g.reference(module.Ssh.ID))
+		}
+		block.Set("folder", optional(module.Folder)).
+			Set("tag_prefix", optional(module.TagPrefix))
+	}
+	return nil
+}
+
+func (g *generator) workspaces() error {
+	var workspaces []*client.WorkspaceEntity
+	if err := g.list(g.organizationPath("workspace"), &workspaces); err != nil {
+		return err
+	}
+
+	for _, workspace := range workspaces {
+		if workspace.Deleted {
+			continue
+		}
+
+		var block *Block
+		if workspace.Source == "empty" {
+			block = g.resource("terrakube_workspace_cli", workspace.Name, workspace.ID, g.importID(workspace.ID)).
+				Set("name", String(workspace.Name)).
+				Set("organization_id", g.reference(g.organizationID)).
+				Set("description", optional(workspace.Description))
+		} else {
+			block = g.resource("terrakube_workspace_vcs", workspace.Name, workspace.ID, g.importID(workspace.ID)).
+				Set("name", String(workspace.Name)).
+				Set("organization_id", g.reference(g.organizationID)).
+				Set("description", optional(workspace.Description)).
+				Set("repository", String(workspace.Source)).
+				Set("branch", String(workspace.Branch)).
+				Set("folder", String(workspace.Folder)).
+				Set("template_id", g.reference(workspace.TemplateId))
+			if workspace.Vcs != nil && workspace.Vcs.ID != "" {
+				block.Set("vcs_id", g.reference(workspace.Vcs.ID))
+			}
+			if workspace.Ssh != nil && workspace.Ssh.ID != "" {
+				block.Set("ssh_id", g.reference(workspace.Ssh.ID))
+			}
+			block.Set("allow_remote_apply", Bool(workspace.AllowRemoteApply))
+		}
+		block.Set("execution_mode", String(workspace.ExecutionMode)).
+			Set("iac_type", String(workspace.IaCType)).
+			Set("iac_version", String(workspace.IaCVersion))
+		if workspace.Project != nil && workspace.Project.ID != "" {
+			block.Set("project_id", g.reference(workspace.Project.ID))
+		}
+		if workspace.ModuleSshKey != nil && *workspace.ModuleSshKey != "" {
+			block.Set("module_ssh_key", g.reference(*workspace.ModuleSshKey))
+		}
+
+		var variables []*client.WorkspaceVariableEntity
+		if err := g.list(g.organizationPath("workspace/"+workspace.ID+"/variable"), &variables); err != nil {
+			return err
+		}
+		for _, variable := range variables {
+			variableBlock := g.resource("terrakube_workspace_variable", workspace.Name+"_"+variable.Key, variable.ID, g.importID(workspace.ID, variable.ID))
+			variableBlock.Set("organization_id", g.reference(g.organizationID)).
+				Set("workspace_id", g.reference(workspace.ID))
+			g.variableAttributes(variableBlock, "workspace_variable_"+variableBlock.Labels[1], variable.Key, variable.Value, &variable.Description, variable.Category, variable.Sensitive, variable.Hcl)
+		}
+	}
+	return nil
+}
+
+// variableAttributes sets the attributes shared by organization variables,
+// collection items and workspace variables. The API doesn't return sensitive
+// values, they are read from an input variable instead.
+func (g *generator) variableAttributes(block *Block, name, key, value string, description *string, category string, sensitive, hcl bool) {
+	block.Set("key", String(key))
+	if sensitive {
+		block.Set("value", g.variable(name, "Value of the sensitive "+key+" variable."))
+	} else {
+		block.Set("value", String(value))
+	}
+	block.Set("description", optional(description)).
+		Set("category", String(category)).
+		Set("sensitive", Bool(sensitive)).
+		Set("hcl", Bool(hcl))
+}
+
+// resource adds a resource and its import block, the address of the resource
+// is used for references to its id.
+func (g *generator) resource(resourceType, name, id, importID string) *Block {
+	if g.labels[resourceType] == nil {
+		g.labels[resourceType] = map[string]bool{}
+	}
+	label := Label(name)
+	for i := 2; g.labels[resourceType][label]; i++ {
+		label = fmt.Sprintf("%s_%d", Label(name), i)
+	}
+	g.labels[resourceType][label] = true
+
+	address := resourceType + "." + label
+	g.addresses[id] = address
+	g.result.Resources++
+
+	g.result.Imports.Add("import").
+		Set("to", Expression(address)).
+		Set("id", String(importID))
+	return g.result.Main.Add("resource", resourceType, label)
+}
+
+// reference refers to the id of an exported resource, or to the id itself
+// when the resource isn't part of the export.
+func (g *generator) reference(id string) Value {
+	if address, ok := g.addresses[id]; ok {
+		return Expression(address + ".id")
+	}
+	return String(id)
+}
+
+// variable declares a sensitive input variable and returns a reference to it.
+func (g *generator) variable(name, description string) Value {
+	name = Label(name)
+	g.result.Variables.Add("variable", name).
+		Set("type", Expression("string")).
+		Set("description", String(description)).
+		Set("sensitive", Bool(true))
+	return Expression("var." + name)
+}
+
+func (g *generator) importID(ids ...string) string {
+	return strings.Join(append([]string{g.organizationID}, ids...), ",")
+}
+
+func (g *generator) organizationPath(collection string) string {
+	return fmt.Sprintf("organization/%s/%s", g.organizationID, collection)
+}
+
+// list reads a collection below /api/v1 into entities, a pointer to a slice
+// of entity pointers, sorted by the first attribute of the entity so the
+// output is stable.
+func (g *generator) list(collection string, entities any) error {
+	request, err := http.NewRequestWithContext(g.ctx, http.MethodGet, fmt.Sprintf("%s/api/v1/%s", g.Endpoint, collection), nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", g.Token))
+	request.Header.Add("Content-Type", "application/vnd.api+json")
+
+	response, err := g.Client.Do(request)
+	if err != nil {
+		return fmt.Errorf("error executing request: %w", err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("error reading %s, response status: %s: %w", collection, response.Status, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("error reading %s, response status: %s, response body: %s", collection, response.Status, string(body))
+	}
+
+	slice := reflect.ValueOf(entities).Elem()
+	data, err := jsonapi.UnmarshalManyPayload(strings.NewReader(string(body)), slice.Type().Elem())
+	if err != nil {
+		return fmt.Errorf("unable to unmarshal %s, response body: %s: %w", collection, string(body), err)
+	}
+	for _, entity := range data {
+		slice.Set(reflect.Append(slice, reflect.ValueOf(entity)))
+	}
+
+	sort.SliceStable(slice.Interface(), func(i, j int) bool {
+		return sortKey(slice.Index(i)) < sortKey(slice.Index(j))
+	})
+	return nil
+}
+
+// sortKey is the name, or the key for variables, of an entity.
+func sortKey(entity reflect.Value) string {
+	for _, field := range []string{"Name", "Key"} {
+		if value := entity.Elem().FieldByName(field); value.IsValid() {
+			return value.String()
+		}
+	}
+	return entity.Elem().FieldByName("ID").String()
+}
+
+func optional(value *string) Value {
+	if value == nil || *value == "" {
+		return nil
+	}
+	return String(*value)
+}
@@ -0,0 +1,106 @@
+package export
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testTemplate = "flow:\n  - type: terraformPlan\n    step: 100\n"
+
+func TestExport(t *testing.T) {
+	payloads := map[string]string{
+		"/api/v1/organization":                `{"data":[{"type":"organization","id":"org-1","attributes":{"name":"acme","executionMode":"remote"}}]}`,
+		"/api/v1/organization/org-1/team":     `{"data":[{"type":"team","id":"team-1","attributes":{"name":"ops","role":"admin"}}]}`,
+		"/api/v1/organization/org-1/template": `{"data":[{"type":"template","id":"tpl-1","attributes":{"name":"Plan and Apply","tcl":"` + base64.StdEncoding.EncodeToString([]byte(testTemplate)) + `"}}]}`,
+		"/api/v1/organization/org-1/vcs": `{"data":[
+			{"type":"vcs","id":"vcs-1","attributes":{"name":"github","vcsType":"GITHUB","connectionType":"OAUTH","clientId":"client"}},
+			{"type":"vcs","id":"vcs-2","attributes":{"name":"github_app","vcsType":"GITHUB","connectionType":"STANDALONE","clientId":"123"}}
+		]}`,
+		"/api/v1/organization/org-1/ssh":       `{"data":[{"type":"ssh","id":"ssh-1","attributes":{"name":"deploy","sshType":"rsa"}}]}`,
+		"/api/v1/organization/org-1/globalvar": `{"data":[{"type":"globalvar","id":"var-1","attributes":{"key":"TOKEN","value":"","category":"ENV","sensitive":true}}]}`,
+		"/api/v1/organization/org-1/workspace": `{"data":[
+			{"type":"workspace","id":"ws-1","attributes":{"name":"network","source":"https://github.com/acme/network.git","branch":"main","defaultTemplate":"tpl-1","iacType":"terraform","terraformVersion":"1.9.0","executionMode":"remote"},"relationships":{"ssh":{"data":{"type":"ssh","id":"ssh-1"}}}},
+			{"type":"workspace","id":"ws-2","attributes":{"name":"old","source":"empty","deleted":true}}
+		]}`,
+		"/api/v1/organization/org-1/workspace/ws-1/variable": `{"data":[{"type":"variable","id":"wsvar-1","attributes":{"key":"region","value":"eu-west-1","category":"TERRAFORM"}}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("unexpected authorization %q", r.Header.Get("Authorization"))
+		}
+		if r.URL.Path == "/api/v1/organization" && r.URL.Query().Get("filter[organization]") != "name=='acme'" {
+			t.Errorf("unexpected organization filter %q", r.URL.Query().Get("filter[organization]"))
+		}
+		payload, ok := payloads[r.URL.Path]
+		if !ok {
+			payload = `{"data":[]}`
+		}
+		_, _ = w.Write([]byte(payload))
+	}))
+	defer server.Close()
+
+	exporter := Exporter{Client: server.Client(), Endpoint: server.URL, Token: "token"}
+	result, err := exporter.Export(context.Background(), "acme")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if result.Resources != 9 {
+		t.Errorf("unexpected resource count %d", result.Resources)
+	}
+
+	files := result.Files()
+	for name, fragments := range map[string][]string{
+		"main.tf": {
+			`resource "terrakube_organization" "acme" {`,
+			"  role            = \"admin\"\n",
+			`resource "terrakube_workspace_vcs" "network" {`,
+			"  template_id        = terrakube_organization_template.plan_and_apply.id\n",
+			"  ssh_id             = terrakube_ssh.deploy.id\n",
+			"  private_key     = var.ssh_deploy_private_key\n",
+			"  content         = <<EOT\n" + testTemplate + "EOT\n",
+			"  client_secret   = var.vcs_github_client_secret\n",
+			"  private_key     = var.vcs_github_app_private_key\n",
+			"  value           = var.organization_variable_token\n",
+			`resource "terrakube_workspace_variable" "network_region" {`,
+		},
+		"imports.tf": {
+			"  to = terrakube_organization.acme\n  id = \"org-1\"\n",
+			"  to = terrakube_workspace_variable.network_region\n  id = \"org-1,ws-1,wsvar-1\"\n",
+		},
+		"variables.tf": {
+			`variable "ssh_deploy_private_key" {`,
+			`variable "organization_variable_token" {`,
+			"  sensitive   = true\n",
+		},
+	} {
+		for _, fragment := range fragments {
+			if !strings.Contains(string(files[name]), fragment) {
+				t.Errorf("%s doesn't contain %q:\n%s", name, fragment, files[name])
+			}
+		}
+	}
+	for _, unexpected := range []string{"vcs_github_private_key", "vcs_github_app_client_secret"} {
+		if strings.Contains(string(files["variables.tf"]), unexpected) {
+			t.Errorf("variables.tf contains %s, a secret the connection type doesn't use:\n%s", unexpected, files["variables.tf"])
+		}
+	}
+	if strings.Contains(string(files["main.tf"]), `"old"`) {
+		t.Errorf("deleted workspace exported:\n%s", files["main.tf"])
+	}
+}
+
+func TestExport_OrganizationNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	exporter := Exporter{Client: server.Client(), Endpoint: server.URL, Token: "token"}
+	if _, err := exporter.Export(context.Background(), "missing"); err == nil || !strings.Contains(err.Error(), `"missing" not found`) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
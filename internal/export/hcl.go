@@ -0,0 +1,181 @@
+package export
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var invalidLabelCharacters = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// Value is the right hand side of an attribute.
+type Value interface {
+	hcl(indent string) string
+}
+
+// String is a string literal.
+type String string
+
+// Bool is a bool literal.
+type Bool bool
+
+// Number is a number literal.
+type Number int64
+
+// Expression is written as is, for references like terrakube_team.ops.id.
+type Expression string
+
+// Object is an object literal with its keys in order.
+type Object []Attribute
+
+func (s String) hcl(indent string) string {
+	value := string(s)
+	// Multi-line values, like templates and scripts, read better as heredocs.
+	if strings.Count(value, "\n") > 1 && strings.HasSuffix(value, "\n") && !strings.Contains(value, "\nEOT\n") {
+		return "<<EOT\n" + escapeTemplate(value) + "EOT"
+	}
+	return quote(value)
+}
+
+func (b Bool) hcl(indent string) string {
+	return strconv.FormatBool(bool(b))
+}
+
+func (n Number) hcl(indent string) string {
+	return strconv.FormatInt(int64(n), 10)
+}
+
+func (e Expression) hcl(indent string) string {
+	return string(e)
+}
+
+func (o Object) hcl(indent string) string {
+	var out strings.Builder
+	out.WriteString("{\n")
+	writeAttributes(&out, []Attribute(o), indent+"  ")
+	out.WriteString(indent + "}")
+	return out.String()
+}
+
+// Attribute is a name and its value.
+type Attribute struct {
+	Name  string
+	Value Value
+}
+
+// Block is a block with labels, attributes and nested blocks.
+type Block struct {
+	Type       string
+	Labels     []string
+	Attributes []Attribute
+	Blocks     []*Block
+}
+
+// Set appends an attribute to the block, skipping nil values.
+func (b *Block) Set(name string, value Value) *Block {
+	if value != nil {
+		b.Attributes = append(b.Attributes, Attribute{Name: name, Value: value})
+	}
+	return b
+}
+
+// File is a list of top level blocks.
+type File struct {
+	Blocks []*Block
+}
+
+// Add appends a block to the file and returns it.
+func (f *File) Add(blockType string, labels ...string) *Block {
+	block := &Block{Type: blockType, Labels: labels}
+	f.Blocks = append(f.Blocks, block)
+	return block
+}
+
+// Bytes writes the file formatted the way terraform fmt does.
+func (f *File) Bytes() []byte {
+	var out strings.Builder
+	for i, block := range f.Blocks {
+		if i > 0 {
+			out.WriteString("\n")
+		}
+		writeBlock(&out, block, "")
+	}
+	return []byte(out.String())
+}
+
+func writeBlock(out *strings.Builder, block *Block, indent string) {
+	out.WriteString(indent + block.Type)
+	for _, label := range block.Labels {
+		out.WriteString(" " + quote(label))
+	}
+	out.WriteString(" {\n")
+	writeAttributes(out, block.Attributes, indent+"  ")
+	for i, nested := range block.Blocks {
+		if i > 0 || len(block.Attributes) > 0 {
+			out.WriteString("\n")
+		}
+		writeBlock(out, nested, indent+"  ")
+	}
+	out.WriteString(indent + "}\n")
+}
+
+// writeAttributes aligns the equals signs of consecutive single line
+// attributes, a multi-line value ends the run.
+func writeAttributes(out *strings.Builder, attributes []Attribute, indent string) {
+	for start := 0; start < len(attributes); {
+		end, width := start, 0
+		for end < len(attributes) {
+			width = max(width, len(attributes[end].Name))
+			end++
+			if strings.Contains(attributes[end-1].Value.hcl(indent), "\n") {
+				break
+			}
+		}
+		for _, attribute := range attributes[start:end] {
+			fmt.Fprintf(out, "%s%-*s = %s\n", indent, width, attribute.Name, attribute.Value.hcl(indent))
+		}
+		start = end
+	}
+}
+
+func quote(value string) string {
+	var out strings.Builder
+	out.WriteString(`"`)
+	for _, r := range value {
+		switch r {
+		case '\\':
+			out.WriteString(`\\`)
+		case '"':
+			out.WriteString(`\"`)
+		case '\n':
+			out.WriteString(`\n`)
+		case '\r':
+			out.WriteString(`\r`)
+		case '\t':
+			out.WriteString(`\t`)
+		default:
+			out.WriteRune(r)
+		}
+	}
+	out.WriteString(`"`)
+	return escapeTemplate(out.String())
+}
+
+// escapeTemplate keeps interpolation and directive sequences literal.
+func escapeTemplate(value string) string {
+	value = strings.ReplaceAll(value, "${", "$${")
+	return strings.ReplaceAll(value, "%{", "%%{")
+}
+
+// Label turns a name into a valid, lower case, resource label.
+func Label(name string) string {
+	label := strings.Trim(invalidLabelCharacters.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if label == "" {
+		return "unnamed"
+	}
+	if label[0] >= '0' && label[0] <= '9' || label[0] == '-' {
+		label = "_" + label
+	}
+	return label
+}
@@ -0,0 +1,52 @@
+package export
+
+import "testing"
+
+func TestFileBytes(t *testing.T) {
+	file := &File{}
+	file.Add("resource", "terrakube_team", "ops").
+		Set("name", String(`say "${hi}"`)).
+		Set("organization_id", Expression("terrakube_organization.acme.id")).
+		Set("plan_job", Bool(true)).
+		Set("content", String("flow:\n  - type: terraformPlan\n")).
+		Set("priority", Number(10))
+	file.Add("import").
+		Set("to", Expression("terrakube_team.ops")).
+		Set("id", String("org-1,team-1"))
+
+	want := `resource "terrakube_team" "ops" {
+  name            = "say \"$${hi}\""
+  organization_id = terrakube_organization.acme.id
+  plan_job        = true
+  content         = <<EOT
+flow:
+  - type: terraformPlan
+EOT
+  priority = 10
+}
+
+import {
+  to = terrakube_team.ops
+  id = "org-1,team-1"
+}
+`
+	if got := string(file.Bytes()); got != want {
+		t.Errorf("unexpected file:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestLabel(t *testing.T) {
+	tests := map[string]string{
+		"Network Prod": "network_prod",
+		"aws/vpc":      "aws_vpc",
+		"1password":    "_1password",
+		"my-module":    "my-module",
+		"***":          "unnamed",
+	}
+
+	for name, want := range tests {
+		if got := Label(name); got != want {
+			t.Errorf("Label(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -1,20 +1,30 @@
 package helpers
 
 import (
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func GetClaimFromToken(jwtToken string, claim string) (string, error) {
+func getClaimsFromToken(jwtToken string) (jwt.MapClaims, error) {
 	token, _, err := new(jwt.Parser).ParseUnverified(jwtToken, jwt.MapClaims{})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok {
-		return "", fmt.Errorf("failed to parse claims")
+		return nil, fmt.Errorf("failed to parse claims")
+	}
+	return claims, nil
+}
+
+func GetClaimFromToken(jwtToken string, claim string) (string, error) {
+	claims, err := getClaimsFromToken(jwtToken)
+	if err != nil {
+		return "", err
 	}
 
 	c, ok := claims[claim].(string)
@@ -27,3 +37,44 @@ func GetClaimFromToken(jwtToken string, claim string) (string, error) {
 func GetIDFromToken(jwtToken string) (string, error) {
 	return GetClaimFromToken(jwtToken, "jti")
 }
+
+// GetExpirationFromToken returns the time of the exp claim. The returned time
+// is zero when the token doesn't expire.
+func GetExpirationFromToken(jwtToken string) (time.Time, error) {
+	claims, err := getClaimsFromToken(jwtToken)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	exp, err := claims.GetExpirationTime()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if exp == nil {
+		return time.Time{}, nil
+	}
+	return exp.UTC(), nil
+}
+
+// GetClaimsFromToken returns every claim of the token as a string. String
+// claims are returned as is, other claims, like exp or aud, JSON encoded.
+func GetClaimsFromToken(jwtToken string) (map[string]string, error) {
+	claims, err := getClaimsFromToken(jwtToken)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string, len(claims))
+	for claim, value := range claims {
+		if s, ok := value.(string); ok {
+			result[claim] = s
+			continue
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("claim %s: %w", claim, err)
+		}
+		result[claim] = string(encoded)
+	}
+	return result, nil
+}
@@ -13,8 +13,12 @@ import (
 	"terraform-provider-terrakube/internal/client"
 
 	"github.com/google/jsonapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -25,6 +29,7 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &CollectionItemResource{}
+var _ resource.ResourceWithIdentity = &CollectionItemResource{}
 var _ resource.ResourceWithImportState = &CollectionItemResource{}
 
 type CollectionItemResource struct {
@@ -34,15 +39,20 @@ type CollectionItemResource struct {
 }
 
 type CollectionItemResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	OrganizationId types.String `tfsdk:"organization_id"`
-	CollectionId   types.String `tfsdk:"collection_id"`
-	Key            types.String `tfsdk:"key"`
-	Value          types.String `tfsdk:"value"`
-	Description    types.String `tfsdk:"description"`
-	Category       types.String `tfsdk:"category"`
-	Sensitive      types.Bool   `tfsdk:"sensitive"`
-	Hcl            types.Bool   `tfsdk:"hcl"`
+	ID                  types.String `tfsdk:"id"`
+	OrganizationId      types.String `tfsdk:"organization_id"`
+	CollectionId        types.String `tfsdk:"collection_id"`
+	Key                 types.String `tfsdk:"key"`
+	Value               types.String `tfsdk:"value"`
+	Description         types.String `tfsdk:"description"`
+	Category            types.String `tfsdk:"category"`
+	Sensitive           types.Bool   `tfsdk:"sensitive"`
+	Hcl                 types.Bool   `tfsdk:"hcl"`
+	ValueWo             types.String `tfsdk:"value_wo"`
+	ValueWoVersion      types.Int64  `tfsdk:"value_wo_version"`
+	ValueHash           types.String `tfsdk:"value_hash"`
+	ValueVersion        types.String `tfsdk:"value_version"`
+	IgnoreRemoteChanges types.Bool   `tfsdk:"ignore_remote_changes"`
 }
 
 func NewCollectionItemResource() resource.Resource {
@@ -78,9 +88,28 @@ func (r *CollectionItemResource) Schema(ctx context.Context, req resource.Schema
 				Description: "Variable key",
 			},
 			"value": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Variable value. Exactly one of `value` or `value_wo` must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("value"), path.MatchRelative().AtParent().AtName("value_wo")),
+				},
+			},
+			"value_wo": schema.StringAttribute{
+				Optional:    true,
 				Sensitive:   true,
-				Description: "Variable value",
+				WriteOnly:   true,
+				Description: "Write-only variable value, never stored in the Terraform state. Requires Terraform 1.11 or later and `value_wo_version`.",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("value_wo_version")),
+				},
+			},
+			"value_wo_version": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Version of `value_wo`. Change it to send a new `value_wo` to Terrakube.",
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("value_wo")),
+				},
 			},
 			"description": schema.StringAttribute{
 				Optional:    true,
@@ -98,6 +127,20 @@ func (r *CollectionItemResource) Schema(ctx context.Context, req resource.Schema
 				Required:    true,
 				Description: "Parse this field as HashiCorp Configuration Language (HCL). This allows you to interpolate values at runtime.",
 			},
+			"value_hash": schema.StringAttribute{
+				Computed:    true,
+				Description: "Salted SHA-256 hash of the value last written by Terraform, used to detect changes made outside of Terraform. Null for sensitive and write-only values, which are never hashed.",
+			},
+			"value_version": schema.StringAttribute{
+				Computed:    true,
+				Description: "Server modification marker recorded when the value was last written. A different marker on refresh means a sensitive or write-only value was changed outside of Terraform. The marker changes with any attribute, so editing only the description outside of Terraform is also planned as a change of the value.",
+			},
+			"ignore_remote_changes": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Keep the last written value when it is changed outside of Terraform instead of planning an update to restore it. Default `false`.",
+			},
 		},
 	}
 }
@@ -144,9 +187,15 @@ func (r *CollectionItemResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
+	value, diags := secretValue(ctx, req.Config, plan.Value, "value_wo")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	bodyRequest := &client.CollectionItemEntity{
 		Key:         plan.Key.ValueString(),
-		Value:       plan.Value.ValueString(),
+		Value:       value.ValueString(),
 		Description: plan.Description.ValueStringPointer(),
 		Sensitive:   plan.Sensitive.ValueBool(),
 		Category:    plan.Category.ValueString(),
@@ -190,9 +239,12 @@ func (r *CollectionItemResource) Create(ctx context.Context, req resource.Create
 
 	tflog.Info(ctx, "Body Response", map[string]any{"bodyResponse": string(bodyResponse)})
 
-	if collectionItem.Sensitive {
-		tflog.Info(ctx, "Collection item value is not included in response, setting values the same as the plan for sensitive=true...")
-		plan.Value = types.StringValue(plan.Value.ValueString())
+	written := writtenValueSnapshot(types.StringNull(), value, collectionItem.Sensitive || plan.Value.IsNull(), collectionItem.UpdatedDate)
+	plan.ValueHash = written.Hash
+	plan.ValueVersion = written.Version
+
+	if collectionItem.Sensitive || plan.Value.IsNull() {
+		tflog.Info(ctx, "Collection item value is not included in response or is write-only, keeping the planned value")
 	} else {
 		tflog.Info(ctx, "Collection item is included in response...")
 		plan.Value = types.StringValue(collectionItem.Value)
@@ -208,9 +260,12 @@ func (r *CollectionItemResource) Create(ctx context.Context, req resource.Create
 	tflog.Info(ctx, "collection item Resource Created", map[string]any{"success": true})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *CollectionItemResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.Diagnostics.Append(setResourceIdentity(ctx, req.State, resp.Identity)...)
+
 	var state CollectionItemResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -299,13 +354,12 @@ func (r *CollectionItemResource) Read(ctx context.Context, req resource.ReadRequ
 
 	tflog.Info(ctx, "Body Response", map[string]any{"bodyResponse": string(bodyResponse)})
 
-	if collectionItem.Sensitive {
-		tflog.Info(ctx, "Collection item value is not included in response, setting values the same as the current state value")
-		state.Value = types.StringValue(state.Value.ValueString())
-	} else {
-		tflog.Info(ctx, "Collection item value is included in response...")
-		state.Value = types.StringValue(collectionItem.Value)
-	}
+	refreshed := refreshSensitiveValue(ctx, sensitiveValueSnapshot{Value: state.Value, Hash: state.ValueHash, Version: state.ValueVersion, WriteOnlyVersion: state.ValueWoVersion}, state.IgnoreRemoteChanges.ValueBool(), collectionItem.Value, collectionItem.Sensitive, collectionItem.UpdatedDate)
+	state.Value = refreshed.Value
+	state.ValueHash = refreshed.Hash
+	state.ValueVersion = refreshed.Version
+	state.ValueWoVersion = refreshed.WriteOnlyVersion
+	state.IgnoreRemoteChanges = types.BoolValue(state.IgnoreRemoteChanges.ValueBool())
 
 	state.Key = types.StringValue(collectionItem.Key)
 	state.Description = types.StringPointerValue(collectionItem.Description)
@@ -334,9 +388,15 @@ func (r *CollectionItemResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
+	value, diags := secretValue(ctx, req.Config, plan.Value, "value_wo")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	bodyRequest := &client.CollectionItemEntity{
 		Key:         plan.Key.ValueString(),
-		Value:       plan.Value.ValueString(),
+		Value:       value.ValueString(),
 		Description: plan.Description.ValueStringPointer(),
 		Category:    plan.Category.ValueString(),
 		Sensitive:   plan.Sensitive.ValueBool(),
@@ -402,9 +462,12 @@ func (r *CollectionItemResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
-	if collectionItem.Sensitive {
-		tflog.Info(ctx, "Collection item is not included in response, setting values the same as the plan for sensitive=true...")
-		plan.Value = types.StringValue(plan.Value.ValueString())
+	written := writtenValueSnapshot(state.ValueHash, value, collectionItem.Sensitive || plan.Value.IsNull(), collectionItem.UpdatedDate)
+	plan.ValueHash = written.Hash
+	plan.ValueVersion = written.Version
+
+	if collectionItem.Sensitive || plan.Value.IsNull() {
+		tflog.Info(ctx, "Collection item value is not included in response or is write-only, keeping the planned value")
 	} else {
 		tflog.Info(ctx, "Collection value is included in response...")
 		plan.Value = types.StringValue(collectionItem.Value)
@@ -418,6 +481,7 @@ func (r *CollectionItemResource) Update(ctx context.Context, req resource.Update
 	plan.Hcl = types.BoolValue(collectionItem.Hcl)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *CollectionItemResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -444,13 +508,41 @@ func (r *CollectionItemResource) Delete(ctx context.Context, req resource.Delete
 	}
 }
 
+func (r *CollectionItemResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema("organization_id", "collection_id", "id")
+}
+
 func (r *CollectionItemResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importStateFromIdentity(ctx, req, resp) {
+		return
+	}
+
+	steps := []importStep{organizationImportStep, {
+		attribute:  "collection_id",
+		kind:       "Collection",
+		collection: "organization/%s/collection",
+		filter:     "collection",
+		fields:     []string{"name"},
+		entity:     new(client.CollectionEntity),
+	}, {
+		attribute:  "id",
+		kind:       "Collection item",
+		collection: "organization/%s/collection/%s/item",
+		filter:     "item",
+		fields:     []string{"key"},
+		entity:     new(client.CollectionItemEntity),
+	}}
+	api := importAPI{client: r.client, endpoint: r.endpoint, token: r.token}
+	if api.importByName(ctx, req, resp, steps...) {
+		return
+	}
+
 	idParts := strings.Split(req.ID, ",")
 
 	if len(idParts) != 3 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: 'organization_ID,collection_ID, ID', Got: %q", req.ID),
+			fmt.Sprintf("Expected import identifier with format: 'organization_ID,collection_ID, ID' or '%s', Got: %q", importNamesFormat(steps...), req.ID),
 		)
 		return
 	}
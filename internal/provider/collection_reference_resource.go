@@ -23,6 +23,7 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &CollectionReferenceResource{}
+var _ resource.ResourceWithIdentity = &CollectionReferenceResource{}
 var _ resource.ResourceWithImportState = &CollectionReferenceResource{}
 
 type CollectionReferenceResource struct {
@@ -180,9 +181,12 @@ func (r *CollectionReferenceResource) Create(ctx context.Context, req resource.C
 	tflog.Info(ctx, "collection reference Resource Created", map[string]any{"success": true})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *CollectionReferenceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.Diagnostics.Append(setResourceIdentity(ctx, req.State, resp.Identity)...)
+
 	var state CollectionReferenceResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -338,6 +342,7 @@ func (r *CollectionReferenceResource) Update(ctx context.Context, req resource.U
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *CollectionReferenceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -364,13 +369,41 @@ func (r *CollectionReferenceResource) Delete(ctx context.Context, req resource.D
 	}
 }
 
+func (r *CollectionReferenceResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema("organization_id", "collection_id", "workspace_id", "id")
+}
+
 func (r *CollectionReferenceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importStateFromIdentity(ctx, req, resp) {
+		return
+	}
+
+	steps := []importStep{organizationImportStep, {
+		attribute:  "collection_id",
+		kind:       "Collection",
+		collection: "organization/%[1]s/collection",
+		filter:     "collection",
+		fields:     []string{"name"},
+		entity:     new(client.CollectionEntity),
+	}, workspaceImportStep, {
+		attribute:  "id",
+		kind:       "Collection reference",
+		collection: "organization/%[1]s/collection/%[2]s/reference",
+		filter:     "reference",
+		parent:     "workspace.id",
+		entity:     new(client.CollectionReferenceEntity),
+	}}
+	api := importAPI{client: r.client, endpoint: r.endpoint, token: r.token}
+	if api.importByName(ctx, req, resp, steps...) {
+		return
+	}
+
 	idParts := strings.Split(req.ID, ",")
 
-	if len(idParts) != 3 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" {
+	if len(idParts) != 4 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" || idParts[3] == "" {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: 'organization_ID,collection_ID, ID', Got: %q", req.ID),
+			fmt.Sprintf("Expected import identifier with format: 'organization_ID,collection_ID,workspace_ID,ID' or '%s', Got: %q", importNamesFormat(steps...), req.ID),
 		)
 		return
 	}
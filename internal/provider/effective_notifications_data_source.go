@@ -0,0 +1,294 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"terraform-provider-terrakube/internal/client"
+
+	"github.com/google/jsonapi"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &EffectiveNotificationsDataSource{}
+	_ datasource.DataSourceWithConfigure = &EffectiveNotificationsDataSource{}
+)
+
+const (
+	notificationScopeOrganization = "ORGANIZATION"
+	notificationScopeWorkspace    = "WORKSPACE"
+)
+
+var effectiveNotificationAttrTypes = map[string]attr.Type{
+	"id":               types.StringType,
+	"name":             types.StringType,
+	"scope":            types.StringType,
+	"channel_type":     types.StringType,
+	"destination_url":  types.StringType,
+	"message_style":    types.StringType,
+	"trigger_statuses": types.ListType{ElemType: types.StringType},
+	"template_ids":     types.ListType{ElemType: types.StringType},
+}
+
+type EffectiveNotificationsDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	OrganizationId types.String `tfsdk:"organization_id"`
+	WorkspaceId    types.String `tfsdk:"workspace_id"`
+	TemplateId     types.String `tfsdk:"template_id"`
+	JobStatus      types.String `tfsdk:"job_status"`
+	Notifications  types.List   `tfsdk:"notifications"`
+}
+
+type effectiveNotificationModel struct {
+	ID              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	Scope           types.String `tfsdk:"scope"`
+	ChannelType     types.String `tfsdk:"channel_type"`
+	DestinationUrl  types.String `tfsdk:"destination_url"`
+	MessageStyle    types.String `tfsdk:"message_style"`
+	TriggerStatuses types.List   `tfsdk:"trigger_statuses"`
+	TemplateIds     types.List   `tfsdk:"template_ids"`
+}
+
+type EffectiveNotificationsDataSource struct {
+	client   *http.Client
+	endpoint string
+	token    string
+}
+
+func NewEffectiveNotificationsDataSource() datasource.DataSource {
+	return &EffectiveNotificationsDataSource{}
+}
+
+func (d *EffectiveNotificationsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*TerrakubeConnectionData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Effective Notifications Data Source Configure Type",
+			fmt.Sprintf("Expected *TerrakubeConnectionData got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	if providerData.InsecureHttpClient {
+		if custom, ok := http.DefaultTransport.(*http.Transport); ok {
+			customTransport := custom.Clone()
+			customTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+			d.client = &http.Client{Transport: customTransport}
+		} else {
+			d.client = &http.Client{}
+		}
+	} else {
+		d.client = &http.Client{}
+	}
+	d.endpoint = providerData.Endpoint
+	d.token = providerData.Token
+
+	tflog.Info(ctx, "Creating Effective Notifications datasource")
+}
+
+func (d *EffectiveNotificationsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_effective_notifications"
+}
+
+func (d *EffectiveNotificationsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "List every notification configuration that fires for a workspace, to audit who is notified for which jobs. Organization-wide " +
+			"configurations and the workspace's own configurations are additive, so the result holds the active configurations of both scopes, " +
+			"organization-wide ones first, each with the job statuses that trigger it and the templates it is narrowed to.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Terrakube workspace id",
+			},
+			"organization_id": schema.StringAttribute{
+				Required:    true,
+				Description: "Terrakube organization id",
+			},
+			"workspace_id": schema.StringAttribute{
+				Required:    true,
+				Description: "Terrakube workspace id",
+			},
+			"template_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list configurations that fire for jobs running this template, those narrowed to it and those that apply to every template.",
+			},
+			"job_status": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list configurations triggered by this job status, for example `failed`.",
+			},
+			"notifications": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Active notification configurations that fire for the workspace.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "Notification configuration ID",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Notification configuration name",
+						},
+						"scope": schema.StringAttribute{
+							Computed:    true,
+							Description: "`ORGANIZATION` for an organization-wide configuration, `WORKSPACE` for one of the workspace's own.",
+						},
+						"channel_type": schema.StringAttribute{
+							Computed:    true,
+							Description: "Delivery channel: `SLACK`, `TEAMS`, `TEAMS_WORKFLOW`, `WEBHOOK`, `EMAIL` or `PAGERDUTY`.",
+						},
+						"destination_url": schema.StringAttribute{
+							Computed:    true,
+							Description: "Destination URL for the channel",
+						},
+						"message_style": schema.StringAttribute{
+							Computed:    true,
+							Description: "Notification message format: `DETAILED` or `SIMPLE`",
+						},
+						"trigger_statuses": schema.ListAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+							Description: "Job statuses that trigger this notification",
+						},
+						"template_ids": schema.ListAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+							Description: "Template IDs this configuration is narrowed to. Empty means it applies to every template.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *EffectiveNotificationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state EffectiveNotificationsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqURL := fmt.Sprintf("%s/api/v1/organization/%s/notificationConfiguration", d.endpoint, state.OrganizationId.ValueString())
+	request, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating effective notifications datasource request", fmt.Sprintf("Error creating effective notifications datasource request: %s", err))
+		return
+	}
+	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", d.token))
+	request.Header.Add("Content-Type", "application/vnd.api+json")
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		resp.Diagnostics.AddError("Error executing effective notifications request", fmt.Sprintf("Error executing effective notifications request: %s", err))
+		return
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading effective notifications response body", fmt.Sprintf("Error reading effective notifications response body: %s", err))
+		return
+	}
+
+	tflog.Info(ctx, "Body Response", map[string]any{"bodyResponse": string(body)})
+
+	list, err := jsonapi.UnmarshalManyPayload(strings.NewReader(string(body)), reflect.TypeOf(new(client.NotificationConfigurationEntity)))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to unmarshal payload", fmt.Sprintf("Unable to unmarshal payload: %s", err))
+		return
+	}
+
+	workspaceID := state.WorkspaceId.ValueString()
+	configs := make([]*client.NotificationConfigurationEntity, 0, len(list))
+	for _, item := range list {
+		cfg, ok := item.(*client.NotificationConfigurationEntity)
+		if !ok {
+			resp.Diagnostics.AddError("Unable to unmarshal payload", "Unexpected type in notification configurations payload")
+			return
+		}
+		if !cfg.Active || (cfg.Workspace != nil && cfg.Workspace.ID != workspaceID) {
+			continue
+		}
+		configs = append(configs, cfg)
+	}
+	sort.SliceStable(configs, func(i, j int) bool {
+		if (configs[i].Workspace == nil) != (configs[j].Workspace == nil) {
+			return configs[i].Workspace == nil
+		}
+		return configs[i].Name < configs[j].Name
+	})
+
+	api := notificationConfigAPI{client: d.client, endpoint: d.endpoint, token: d.token}
+	notifications := make([]effectiveNotificationModel, 0, len(configs))
+	for _, cfg := range configs {
+		triggers, diags := api.fetchNotificationTriggers(ctx, cfg.ID)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		statuses := make([]string, 0, len(triggers))
+		for _, t := range triggers {
+			statuses = append(statuses, t.JobStatus)
+		}
+		if !state.JobStatus.IsNull() && !slices.Contains(statuses, state.JobStatus.ValueString()) {
+			continue
+		}
+
+		templateIds, diags := api.fetchNotificationConfigurationTemplateIDs(ctx, cfg.ID)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !state.TemplateId.IsNull() && len(templateIds) > 0 && !slices.Contains(templateIds, state.TemplateId.ValueString()) {
+			continue
+		}
+
+		triggerList, listDiags := types.ListValueFrom(ctx, types.StringType, statuses)
+		resp.Diagnostics.Append(listDiags...)
+		templateList, listDiags := types.ListValueFrom(ctx, types.StringType, templateIds)
+		resp.Diagnostics.Append(listDiags...)
+
+		scope := notificationScopeOrganization
+		if cfg.Workspace != nil {
+			scope = notificationScopeWorkspace
+		}
+		notifications = append(notifications, effectiveNotificationModel{
+			ID:              types.StringValue(cfg.ID),
+			Name:            types.StringValue(cfg.Name),
+			Scope:           types.StringValue(scope),
+			ChannelType:     types.StringValue(cfg.ChannelType),
+			DestinationUrl:  types.StringValue(cfg.DestinationUrl),
+			MessageStyle:    types.StringValue(cfg.MessageStyle),
+			TriggerStatuses: triggerList,
+			TemplateIds:     templateList,
+		})
+	}
+
+	notificationList, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: effectiveNotificationAttrTypes}, notifications)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ID = types.StringValue(workspaceID)
+	state.Notifications = notificationList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
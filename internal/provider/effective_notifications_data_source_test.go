@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func effectiveNotificationsServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/organization/org-1/notificationConfiguration", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[
+			{"type":"notification_configuration","id":"cfg-ws","attributes":{"name":"networking-alerts","channelType":"SLACK","destinationUrl":"https://ws","active":true,"messageStyle":"SIMPLE"},
+			 "relationships":{"workspace":{"data":{"type":"workspace","id":"ws-1"}}}},
+			{"type":"notification_configuration","id":"cfg-other-ws","attributes":{"name":"other-alerts","channelType":"SLACK","destinationUrl":"https://other","active":true,"messageStyle":"SIMPLE"},
+			 "relationships":{"workspace":{"data":{"type":"workspace","id":"ws-2"}}}},
+			{"type":"notification_configuration","id":"cfg-org-pager","attributes":{"name":"pager","channelType":"PAGERDUTY","destinationUrl":"https://events.pagerduty.com","active":true,"messageStyle":"DETAILED"}},
+			{"type":"notification_configuration","id":"cfg-org-audit","attributes":{"name":"audit","channelType":"WEBHOOK","destinationUrl":"https://audit","active":true,"messageStyle":"DETAILED"}},
+			{"type":"notification_configuration","id":"cfg-org-disabled","attributes":{"name":"disabled","channelType":"SLACK","destinationUrl":"https://disabled","active":false,"messageStyle":"DETAILED"}}
+		]}`)
+	})
+	triggers := map[string]string{"cfg-ws": "failed", "cfg-org-pager": "failed", "cfg-org-audit": "completed"}
+	templates := map[string]string{"cfg-ws": "", "cfg-org-pager": `{"type":"template","id":"tpl-apply"}`, "cfg-org-audit": ""}
+	for id, status := range triggers {
+		mux.HandleFunc(fmt.Sprintf("/api/v1/notification_configuration/%s/triggers", id), func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"data":[{"type":"notification_trigger","id":"trig-%s","attributes":{"jobStatus":%q}}]}`, id, status)
+		})
+		template := templates[id]
+		mux.HandleFunc(fmt.Sprintf("/api/v1/notification_configuration/%s/templates", id), func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"data":[%s]}`, template)
+		})
+	}
+
+	return httptest.NewServer(mux)
+}
+
+func readEffectiveNotifications(t *testing.T, overrides map[string]tftypes.Value) []effectiveNotificationModel {
+	t.Helper()
+	ctx := context.Background()
+
+	d := &EffectiveNotificationsDataSource{}
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	objType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("expected schema type to be a tftypes.Object")
+	}
+
+	server := effectiveNotificationsServer(t)
+	defer server.Close()
+	d.client = server.Client()
+	d.endpoint = server.URL
+	d.token = "test-token"
+
+	overrides["organization_id"] = tftypes.NewValue(tftypes.String, "org-1")
+	overrides["workspace_id"] = tftypes.NewValue(tftypes.String, "ws-1")
+	req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: buildObjectValue(objType, overrides)}}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+
+	d.Read(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var result EffectiveNotificationsDataSourceModel
+	if diags := resp.State.Get(ctx, &result); diags.HasError() {
+		t.Fatalf("reading resulting state: %v", diags)
+	}
+	var notifications []effectiveNotificationModel
+	if diags := result.Notifications.ElementsAs(ctx, &notifications, false); diags.HasError() {
+		t.Fatalf("reading notifications: %v", diags)
+	}
+	return notifications
+}
+
+func effectiveNotificationIDs(notifications []effectiveNotificationModel) []string {
+	ids := make([]string, 0, len(notifications))
+	for _, notification := range notifications {
+		ids = append(ids, notification.Scope.ValueString()+":"+notification.ID.ValueString())
+	}
+	return ids
+}
+
+// TestEffectiveNotificationsDataSource_Read covers the resolution: active
+// organization-wide configurations first, then the workspace's own, never
+// another workspace's or a disabled one.
+func TestEffectiveNotificationsDataSource_Read(t *testing.T) {
+	notifications := readEffectiveNotifications(t, map[string]tftypes.Value{})
+
+	got := fmt.Sprint(effectiveNotificationIDs(notifications))
+	want := "[ORGANIZATION:cfg-org-audit ORGANIZATION:cfg-org-pager WORKSPACE:cfg-ws]"
+	if got != want {
+		t.Fatalf("notifications = %s, want %s", got, want)
+	}
+	if templates := notifications[1].TemplateIds.String(); templates != `["tpl-apply"]` {
+		t.Errorf("template_ids = %s, want [\"tpl-apply\"]", templates)
+	}
+	if statuses := notifications[2].TriggerStatuses.String(); statuses != `["failed"]` {
+		t.Errorf("trigger_statuses = %s, want [\"failed\"]", statuses)
+	}
+}
+
+func TestEffectiveNotificationsDataSource_Read_Filters(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]tftypes.Value
+		want      string
+	}{
+		{
+			name:      "job status",
+			overrides: map[string]tftypes.Value{"job_status": tftypes.NewValue(tftypes.String, "failed")},
+			want:      "[ORGANIZATION:cfg-org-pager WORKSPACE:cfg-ws]",
+		},
+		{
+			name:      "narrowed template",
+			overrides: map[string]tftypes.Value{"template_id": tftypes.NewValue(tftypes.String, "tpl-apply")},
+			want:      "[ORGANIZATION:cfg-org-audit ORGANIZATION:cfg-org-pager WORKSPACE:cfg-ws]",
+		},
+		{
+			name:      "other template",
+			overrides: map[string]tftypes.Value{"template_id": tftypes.NewValue(tftypes.String, "tpl-plan")},
+			want:      "[ORGANIZATION:cfg-org-audit WORKSPACE:cfg-ws]",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := fmt.Sprint(effectiveNotificationIDs(readEffectiveNotifications(t, test.overrides)))
+			if got != test.want {
+				t.Errorf("notifications = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
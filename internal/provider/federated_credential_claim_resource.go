@@ -21,6 +21,7 @@ import (
 )
 
 var _ resource.Resource = &FederatedCredentialClaimResource{}
+var _ resource.ResourceWithIdentity = &FederatedCredentialClaimResource{}
 var _ resource.ResourceWithImportState = &FederatedCredentialClaimResource{}
 
 type FederatedCredentialClaimResource struct {
@@ -161,9 +162,12 @@ func (r *FederatedCredentialClaimResource) Create(ctx context.Context, req resou
 	tflog.Info(ctx, "Federated Credential Claim Resource Created", map[string]any{"success": true})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *FederatedCredentialClaimResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.Diagnostics.Append(setResourceIdentity(ctx, req.State, resp.Identity)...)
+
 	var state FederatedCredentialClaimResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -292,6 +296,7 @@ func (r *FederatedCredentialClaimResource) Update(ctx context.Context, req resou
 	plan.ClaimValue = types.StringValue(claim.ClaimValue)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *FederatedCredentialClaimResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -315,13 +320,41 @@ func (r *FederatedCredentialClaimResource) Delete(ctx context.Context, req resou
 	}
 }
 
+func (r *FederatedCredentialClaimResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema("federated_credential_id", "id")
+}
+
 func (r *FederatedCredentialClaimResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importStateFromIdentity(ctx, req, resp) {
+		return
+	}
+
+	steps := []importStep{{
+		attribute:  "federated_credential_id",
+		kind:       "Federated credential",
+		collection: "federated",
+		filter:     "federated",
+		fields:     []string{"name"},
+		entity:     new(client.FederatedEntity),
+	}, {
+		attribute:  "id",
+		kind:       "Claim",
+		collection: "federated/%s/claims",
+		filter:     "federated_claim",
+		fields:     []string{"claimKey"},
+		entity:     new(client.FederatedClaimEntity),
+	}}
+	api := importAPI{client: r.client, endpoint: r.endpoint, token: r.token}
+	if api.importByName(ctx, req, resp, steps...) {
+		return
+	}
+
 	idParts := strings.Split(req.ID, ",")
 
 	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: 'federated_credential_ID,ID', Got: %q", req.ID),
+			fmt.Sprintf("Expected import identifier with format: 'federated_credential_ID,ID' or '%s', Got: %q", importNamesFormat(steps...), req.ID),
 		)
 		return
 	}
@@ -21,6 +21,7 @@ import (
 )
 
 var _ resource.Resource = &FederatedCredentialResource{}
+var _ resource.ResourceWithIdentity = &FederatedCredentialResource{}
 var _ resource.ResourceWithImportState = &FederatedCredentialResource{}
 
 type FederatedCredentialResource struct {
@@ -160,9 +161,12 @@ func (r *FederatedCredentialResource) Create(ctx context.Context, req resource.C
 	tflog.Info(ctx, "Federated Credential Resource Created", map[string]any{"success": true})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *FederatedCredentialResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.Diagnostics.Append(setResourceIdentity(ctx, req.State, resp.Identity)...)
+
 	var state FederatedCredentialResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -294,6 +298,7 @@ func (r *FederatedCredentialResource) Update(ctx context.Context, req resource.U
 	plan.Audience = types.StringValue(federated.Audience)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *FederatedCredentialResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -317,6 +322,27 @@ func (r *FederatedCredentialResource) Delete(ctx context.Context, req resource.D
 	}
 }
 
+func (r *FederatedCredentialResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema("id")
+}
+
 func (r *FederatedCredentialResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importStateFromIdentity(ctx, req, resp) {
+		return
+	}
+
+	steps := []importStep{{
+		attribute:  "id",
+		kind:       "Federated credential",
+		collection: "federated",
+		filter:     "federated",
+		fields:     []string{"name"},
+		entity:     new(client.FederatedEntity),
+	}}
+	api := importAPI{client: r.client, endpoint: r.endpoint, token: r.token}
+	if api.importByName(ctx, req, resp, steps...) {
+		return
+	}
+
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func runFunction(t *testing.T, f function.Function, result attr.Value, arguments ...attr.Value) *function.RunResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	resp := &function.RunResponse{Result: function.NewResultData(result)}
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(arguments)}, resp)
+	return resp
+}
+
+func stringTuple(values ...string) attr.Value {
+	elementTypes := make([]attr.Type, len(values))
+	elements := make([]attr.Value, len(values))
+	for i, value := range values {
+		elementTypes[i] = types.StringType
+		elements[i] = types.StringValue(value)
+	}
+	return types.TupleValueMust(elementTypes, elements)
+}
+
+func TestWorkspaceImportIDFunction(t *testing.T) {
+	resp := runFunction(t, NewWorkspaceImportIDFunction(), types.StringUnknown(),
+		types.StringValue("org-1"), types.StringValue("ws-1"), stringTuple("var-1"))
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+	if got := resp.Result.Value(); !got.Equal(types.StringValue("org-1,ws-1,var-1")) {
+		t.Errorf("unexpected result %s", got)
+	}
+
+	resp = runFunction(t, NewWorkspaceImportIDFunction(), types.StringUnknown(),
+		types.StringValue("org-1"), types.StringValue(""), stringTuple())
+	if resp.Error == nil {
+		t.Errorf("expected an error for an empty workspace id")
+	}
+}
+
+func TestParseImportIDFunction(t *testing.T) {
+	resp := runFunction(t, NewParseImportIDFunction(), types.ObjectUnknown(parsedImportIDAttrTypes), types.StringValue("org-1,col-1,item-1"))
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+	want := types.ObjectValueMust(parsedImportIDAttrTypes, map[string]attr.Value{
+		"organization_id": types.StringValue("org-1"),
+		"parent_ids":      types.ListValueMust(types.StringType, []attr.Value{types.StringValue("col-1")}),
+		"id":              types.StringValue("item-1"),
+	})
+	if got := resp.Result.Value(); !got.Equal(want) {
+		t.Errorf("unexpected result %s", got)
+	}
+
+	for _, id := range []string{"org-1", "org-1,", ",id"} {
+		resp = runFunction(t, NewParseImportIDFunction(), types.ObjectUnknown(parsedImportIDAttrTypes), types.StringValue(id))
+		if resp.Error == nil {
+			t.Errorf("expected an error for %q", id)
+		}
+	}
+}
+
+func TestTokenClaimsFunction(t *testing.T) {
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"jti": "token-1", "exp": 1700000000}).SignedString([]byte("test-key"))
+	if err != nil {
+		t.Fatalf("signing test token: %v", err)
+	}
+
+	resp := runFunction(t, NewTokenClaimsFunction(), types.MapUnknown(types.StringType), types.StringValue(signed))
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+	want := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"jti": types.StringValue("token-1"),
+		"exp": types.StringValue("1700000000"),
+	})
+	if got := resp.Result.Value(); !got.Equal(want) {
+		t.Errorf("unexpected result %s", got)
+	}
+
+	resp = runFunction(t, NewTokenClaimsFunction(), types.MapUnknown(types.StringType), types.StringValue("not-a-token"))
+	if resp.Error == nil || !strings.Contains(resp.Error.Error(), "Unable to parse token") {
+		t.Errorf("unexpected error: %v", resp.Error)
+	}
+}
+
+func TestQuartzNextFunction(t *testing.T) {
+	resp := runFunction(t, NewQuartzNextFunction(), types.ListUnknown(types.StringType),
+		types.StringValue("0 0 12 ? * MON-FRI"), types.Int64Value(2), types.StringValue("2024-06-07T13:00:00+02:00"))
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+	want := types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("2024-06-10T12:00:00+02:00"),
+		types.StringValue("2024-06-11T12:00:00+02:00"),
+	})
+	if got := resp.Result.Value(); !got.Equal(want) {
+		t.Errorf("unexpected result %s", got)
+	}
+
+	resp = runFunction(t, NewQuartzNextFunction(), types.ListUnknown(types.StringType),
+		types.StringValue("0 0 12 * * MON"), types.Int64Value(2), types.StringValue("2024-06-07T13:00:00Z"))
+	if resp.Error == nil || !strings.Contains(resp.Error.Error(), "Error parsing schedule") {
+		t.Errorf("unexpected error: %v", resp.Error)
+	}
+
+	resp = runFunction(t, NewQuartzNextFunction(), types.ListUnknown(types.StringType),
+		types.StringValue("0 0 12 ? * MON-FRI"), types.Int64Value(2), types.StringValue("2024-06-07"))
+	if resp.Error == nil || !strings.Contains(resp.Error.Error(), "Error parsing after") {
+		t.Errorf("unexpected error: %v", resp.Error)
+	}
+}
+
+func TestWebhookFilterMatchesFunction(t *testing.T) {
+	list := func(values ...string) attr.Value {
+		elements := make([]attr.Value, len(values))
+		for i, value := range values {
+			elements[i] = types.StringValue(value)
+		}
+		return types.ListValueMust(types.StringType, elements)
+	}
+
+	resp := runFunction(t, NewWebhookFilterMatchesFunction(), types.ListUnknown(types.StringType),
+		list("modules/.*\\.tf", "README.md"), list("modules/vpc/main.tf", "README.md", "docs/README.md", "modules/vpc/main.tf.bak"))
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+	if got, want := resp.Result.Value(), list("modules/vpc/main.tf", "README.md"); !got.Equal(want) {
+		t.Errorf("unexpected result %s", got)
+	}
+
+	resp = runFunction(t, NewWebhookFilterMatchesFunction(), types.ListUnknown(types.StringType), list("*.tf"), list("main.tf"))
+	if resp.Error == nil || !strings.Contains(resp.Error.Error(), "rather than globs") {
+		t.Errorf("unexpected error: %v", resp.Error)
+	}
+}
@@ -0,0 +1,236 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"terraform-provider-terrakube/internal/client"
+
+	"github.com/google/jsonapi"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var snakeCase = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// importStep finds one of the ids of an import identifier written with names.
+// Each step looks up a single entity in collection, a path below /api/v1 that
+// is formatted with the ids found by the previous steps, filtering fields by
+// the next segments of the identifier. A step with a parent field also
+// filters it by the id found by the previous step, and a step with neither
+// expects the collection to hold a single entity. A step without attribute
+// only finds an id for the next steps, for resources that don't store it.
+type importStep struct {
+	attribute  string
+	kind       string
+	collection string
+	filter     string
+	fields     []string
+	parent     string
+	entity     any
+}
+
+var organizationImportStep = importStep{
+	attribute:  "organization_id",
+	kind:       "Organization",
+	collection: "organization",
+	filter:     "organization",
+	fields:     []string{"name"},
+	entity:     new(client.OrganizationEntity),
+}
+
+var workspaceImportStep = importStep{
+	attribute:  "workspace_id",
+	kind:       "Workspace",
+	collection: "organization/%[1]s/workspace",
+	filter:     "workspace",
+	fields:     []string{"name"},
+	entity:     new(client.WorkspaceEntity),
+}
+
+// as returns the step setting its id into another attribute.
+func (s importStep) as(attribute string) importStep {
+	s.attribute = attribute
+	return s
+}
+
+// importAPI resolves import identifiers written with names, like
+// org-name/workspace-name, through the same RSQL name filters the data sources
+// use.
+type importAPI struct {
+	client   *http.Client
+	endpoint string
+	token    string
+}
+
+// importNames splits an import identifier written with names into its
+// segments. Identifiers made of ids, comma separated or a single UUID, are
+// left to the caller.
+func importNames(id string) ([]string, bool) {
+	if id == "" || strings.Contains(id, ",") || uuidPattern.MatchString(id) {
+		return nil, false
+	}
+	return strings.Split(id, "/"), true
+}
+
+// importNamesFormat describes the names an import identifier is written with
+// for the given steps, for example 'organization_name/workspace_name'.
+func importNamesFormat(steps ...importStep) string {
+	var segments []string
+	for _, step := range steps {
+		kind := strings.ToLower(strings.ReplaceAll(step.kind, " ", "_"))
+		for _, field := range step.fields {
+			segment := snakeCase.ReplaceAllString(field, "${1}_${2}")
+			if segment = strings.ToLower(segment); !strings.HasPrefix(segment, kind) {
+				segment = kind + "_" + segment
+			}
+			segments = append(segments, segment)
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// importByName imports the resource from an identifier written with names,
+// setting the attribute of every step. It returns false, without touching the
+// response, when the identifier is written with ids.
+func (a importAPI) importByName(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, steps ...importStep) bool {
+	names, ok := importNames(req.ID)
+	if !ok {
+		return false
+	}
+
+	segments := 0
+	for _, step := range steps {
+		segments += len(step.fields)
+	}
+	if len(names) != segments || slices.Contains(names, "") {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: '%s', Got: %q", importNamesFormat(steps...), req.ID),
+		)
+		return true
+	}
+
+	var ids []any
+	for _, step := range steps {
+		var filters []string
+		for _, field := range step.fields {
+			filters = append(filters, fmt.Sprintf("%s=='%s'", field, strings.ReplaceAll(names[0], "'", `\'`)))
+			names = names[1:]
+		}
+		if step.parent != "" {
+			filters = append(filters, fmt.Sprintf("%s=='%s'", step.parent, ids[len(ids)-1]))
+		}
+
+		id, err := a.findID(ctx, fmt.Sprintf(step.collection, ids...), step.filter, strings.Join(filters, ";"), step.entity)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Error importing %s", strings.ToLower(step.kind)), fmt.Sprintf("%s %s: %s", step.kind, strings.Join(filters, ";"), err))
+			return true
+		}
+		tflog.Info(ctx, "Import identifier resolved", map[string]any{"attribute": step.attribute, "id": id})
+
+		if step.attribute != "" {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(step.attribute), id)...)
+		}
+		ids = append(ids, id)
+	}
+	return true
+}
+
+// findID returns the id of the only entity of the collection matching the RSQL
+// filter, or of the only entity of the collection when the filter is empty.
+func (a importAPI) findID(ctx context.Context, collection, filterType, filter string, entity any) (string, error) {
+	data, err := a.listEntities(ctx, collection, filterType, filter, entity)
+	if err != nil {
+		return "", err
+	}
+
+	switch len(data) {
+	case 0:
+		return "", fmt.Errorf("not found")
+	case 1:
+		return entityID(data[0]), nil
+	default:
+		return "", fmt.Errorf("matches %d entities, import it by id instead", len(data))
+	}
+}
+
+// listPageSize is the number of entities requested per page by listEntities.
+const listPageSize = 500
+
+// listEntities returns the entities of the collection matching the RSQL
+// filter, or all of them when the filter is empty, following the pages of
+// the collection until one comes back short.
+func (a importAPI) listEntities(ctx context.Context, collection, filterType, filter string, entity any) ([]any, error) {
+	var data []any
+	for number := 1; ; number++ {
+		query := url.Values{}
+		if filter != "" {
+			query.Set(fmt.Sprintf("filter[%s]", filterType), filter)
+		}
+		query.Set("sort", "id")
+		query.Set("page[number]", strconv.Itoa(number))
+		query.Set("page[size]", strconv.Itoa(listPageSize))
+
+		page, err := a.listPage(ctx, fmt.Sprintf("%s/api/v1/%s?%s", a.endpoint, collection, query.Encode()), entity)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, page...)
+
+		// A short page is the last one, and a page larger than requested
+		// means the server ignored the pagination and returned everything.
+		if len(page) != listPageSize {
+			return data, nil
+		}
+	}
+}
+
+// listPage returns the entities of a single page of a collection.
+func (a importAPI) listPage(ctx context.Context, reqURL string, entity any) ([]any, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", a.token))
+	request.Header.Add("Content-Type", "application/vnd.api+json")
+
+	response, err := a.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("error executing request: %w", err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response, response status: %s: %w", response.Status, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status: %s, response body: %s", response.Status, string(body))
+	}
+
+	data, err := jsonapi.UnmarshalManyPayload(strings.NewReader(string(body)), reflect.TypeOf(entity))
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal payload, response body: %s: %w", string(body), err)
+	}
+	return data, nil
+}
+
+// entityField returns a string field of an entity returned by listEntities.
+func entityField(entity any, field string) string {
+	return reflect.ValueOf(entity).Elem().FieldByName(field).String()
+}
+
+func entityID(entity any) string {
+	return entityField(entity, "ID")
+}
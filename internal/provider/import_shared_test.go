@@ -0,0 +1,248 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestImportNames(t *testing.T) {
+	tests := map[string]bool{
+		"acme/network":                         true,
+		"acme":                                 true,
+		"org-1,ws-1":                           false,
+		"00000000-0000-0000-0000-000000000000": false,
+		"":                                     false,
+	}
+
+	for id, want := range tests {
+		if _, got := importNames(id); got != want {
+			t.Errorf("importNames(%q) = %t, want %t", id, got, want)
+		}
+	}
+}
+
+func TestImportNamesFormat(t *testing.T) {
+	claim := importStep{kind: "Claim", fields: []string{"claimKey"}}
+	variable := importStep{kind: "Variable", fields: []string{"key"}}
+
+	if got := importNamesFormat(organizationImportStep, workspaceImportStep, variable); got != "organization_name/workspace_name/variable_key" {
+		t.Errorf("unexpected format %q", got)
+	}
+	if got := importNamesFormat(claim); got != "claim_key" {
+		t.Errorf("unexpected format %q", got)
+	}
+}
+
+func testImportWorkspaceVariable(t *testing.T, endpoint, id string) *resource.ImportStateResponse {
+	t.Helper()
+	return testImportState(t, &WorkspaceVariableResource{client: http.DefaultClient, endpoint: endpoint, token: "token"}, id)
+}
+
+func testImportState(t *testing.T, r resource.ResourceWithImportState, id string) *resource.ImportStateResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	resp := &resource.ImportStateResponse{State: tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: id}, resp)
+	return resp
+}
+
+func TestImportByName_WorkspaceVariable(t *testing.T) {
+	ctx := context.Background()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/organization", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("filter[organization]"); got != "name=='acme'" {
+			t.Errorf("unexpected organization filter %q", got)
+		}
+		_, _ = w.Write([]byte(`{"data":[{"type":"organization","id":"org-1","attributes":{"name":"acme"}}]}`))
+	})
+	mux.HandleFunc("/api/v1/organization/org-1/workspace", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("filter[workspace]"); got != "name=='network'" {
+			t.Errorf("unexpected workspace filter %q", got)
+		}
+		_, _ = w.Write([]byte(`{"data":[{"type":"workspace","id":"ws-1","attributes":{"name":"network"}}]}`))
+	})
+	mux.HandleFunc("/api/v1/organization/org-1/workspace/ws-1/variable", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("filter[variable]") == "key=='missing'" {
+			_, _ = w.Write([]byte(`{"data":[]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"type":"variable","id":"var-1","attributes":{"key":"region"}}]}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	resp := testImportWorkspaceVariable(t, server.URL, "acme/network/region")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	for attribute, want := range map[string]string{"organization_id": "org-1", "workspace_id": "ws-1", "id": "var-1"} {
+		var got types.String
+		resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root(attribute), &got)...)
+		if got.ValueString() != want {
+			t.Errorf("%s = %s, want %s", attribute, got, want)
+		}
+	}
+
+	resp = testImportWorkspaceVariable(t, server.URL, "acme/network/missing")
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected a missing variable to fail the import")
+	}
+
+	resp = testImportWorkspaceVariable(t, server.URL, "acme/network")
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Unexpected Import Identifier" {
+		t.Errorf("expected an identifier with missing names to be rejected, got %v", resp.Diagnostics)
+	}
+}
+
+func TestImportByName_KeepsIds(t *testing.T) {
+	ctx := context.Background()
+
+	resp := testImportWorkspaceVariable(t, "http://127.0.0.1:0", "org-1,ws-1,var-1")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	var id types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	if id.ValueString() != "var-1" {
+		t.Errorf("expected the comma separated ids to be used as is, got %s", id)
+	}
+}
+
+func assertImportedAttributes(t *testing.T, resp *resource.ImportStateResponse, want map[string]string) {
+	t.Helper()
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	for attribute, value := range want {
+		var got types.String
+		resp.Diagnostics.Append(resp.State.GetAttribute(context.Background(), path.Root(attribute), &got)...)
+		if got.ValueString() != value {
+			t.Errorf("%s = %s, want %s", attribute, got, value)
+		}
+	}
+}
+
+func TestImportByName_WorkspaceSchedule(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/organization", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[{"type":"organization","id":"org-1","attributes":{"name":"acme"}}]}`))
+	})
+	mux.HandleFunc("/api/v1/organization/org-1/workspace", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[{"type":"workspace","id":"ws-1","attributes":{"name":"network"}}]}`))
+	})
+	mux.HandleFunc("/api/v1/organization/org-1/template", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("filter[template]"); got != "name=='nightly-plan'" {
+			t.Errorf("unexpected template filter %q", got)
+		}
+		_, _ = w.Write([]byte(`{"data":[{"type":"template","id":"tmpl-1","attributes":{"name":"nightly-plan"}}]}`))
+	})
+	mux.HandleFunc("/api/v1/organization/org-1/workspace/ws-1/schedule", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("filter[schedule]"); got != "templateReference=='tmpl-1'" {
+			t.Errorf("unexpected schedule filter %q", got)
+		}
+		_, _ = w.Write([]byte(`{"data":[{"type":"schedule","id":"sched-1","attributes":{"cron":"0 0 2 * * ?","templateReference":"tmpl-1"}}]}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	r := &WorkspaceScheduleResource{client: server.Client(), endpoint: server.URL, token: "token"}
+	assertImportedAttributes(t, testImportState(t, r, "acme/network/nightly-plan"),
+		map[string]string{"workspace_id": "ws-1", "template_id": "tmpl-1", "id": "sched-1"})
+
+	// The schedule doesn't store the organization, its id is ignored.
+	assertImportedAttributes(t, testImportState(t, r, "org-1,ws-1,sched-1"), map[string]string{"workspace_id": "ws-1", "id": "sched-1"})
+	assertImportedAttributes(t, testImportState(t, r, "ws-1,sched-1"), map[string]string{"workspace_id": "ws-1", "id": "sched-1"})
+}
+
+func TestImportByName_CollectionReference(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/organization", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[{"type":"organization","id":"org-1","attributes":{"name":"acme"}}]}`))
+	})
+	mux.HandleFunc("/api/v1/organization/org-1/collection", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[{"type":"collection","id":"col-1","attributes":{"name":"shared"}}]}`))
+	})
+	mux.HandleFunc("/api/v1/organization/org-1/workspace", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[{"type":"workspace","id":"ws-1","attributes":{"name":"network"}}]}`))
+	})
+	mux.HandleFunc("/api/v1/organization/org-1/collection/col-1/reference", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("filter[reference]"); got != "workspace.id=='ws-1'" {
+			t.Errorf("unexpected reference filter %q", got)
+		}
+		_, _ = w.Write([]byte(`{"data":[{"type":"reference","id":"ref-1","attributes":{"description":"network"}}]}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	r := &CollectionReferenceResource{client: server.Client(), endpoint: server.URL, token: "token"}
+	assertImportedAttributes(t, testImportState(t, r, "acme/shared/network"),
+		map[string]string{"organization_id": "org-1", "collection_id": "col-1", "workspace_id": "ws-1", "id": "ref-1"})
+}
+
+func TestImportByName_WorkspaceWebhookEvent(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/organization", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[{"type":"organization","id":"org-1","attributes":{"name":"acme"}}]}`))
+	})
+	mux.HandleFunc("/api/v1/organization/org-1/workspace", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[{"type":"workspace","id":"ws-1","attributes":{"name":"network"}}]}`))
+	})
+	mux.HandleFunc("/api/v1/organization/org-1/workspace/ws-1/webhook", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[{"type":"webhook","id":"wh-1","attributes":{"migratedV2":true}}]}`))
+	})
+	mux.HandleFunc("/api/v1/organization/org-1/workspace/ws-1/webhook/wh-1/events", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("filter[webhook_event]"); got != "priority=='2'" {
+			t.Errorf("unexpected event filter %q", got)
+		}
+		_, _ = w.Write([]byte(`{"data":[{"type":"webhook_event","id":"ev-2","attributes":{"priority":2},` +
+			`"relationships":{"webhook":{"data":{"type":"webhook","id":"wh-1"}}}}]}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	r := &WorkspaceWebhookEventResource{client: server.Client(), endpoint: server.URL, token: "token"}
+	assertImportedAttributes(t, testImportState(t, r, "acme/network/2"), map[string]string{"webhook_id": "wh-1", "id": "ev-2"})
+}
+
+func TestImportByName_WorkspaceTag(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/organization", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[{"type":"organization","id":"org-1","attributes":{"name":"acme"}}]}`))
+	})
+	mux.HandleFunc("/api/v1/organization/org-1/workspace", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[{"type":"workspace","id":"ws-1","attributes":{"name":"network"}}]}`))
+	})
+	mux.HandleFunc("/api/v1/organization/org-1/tag", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[{"type":"tag","id":"tag-1","attributes":{"name":"prod"}}]}`))
+	})
+	mux.HandleFunc("/api/v1/organization/org-1/workspace/ws-1/workspaceTag", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("filter[workspacetag]"); got != "tagId=='tag-1'" {
+			t.Errorf("unexpected workspace tag filter %q", got)
+		}
+		_, _ = w.Write([]byte(`{"data":[{"type":"workspacetag","id":"wt-1","attributes":{"tagId":"tag-1"}}]}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	r := &WorkspaceTagResource{client: server.Client(), endpoint: server.URL, token: "token"}
+	assertImportedAttributes(t, testImportState(t, r, "acme/network/prod"),
+		map[string]string{"organization_id": "org-1", "workspace_id": "ws-1", "tag_id": "tag-1", "id": "wt-1"})
+	assertImportedAttributes(t, testImportState(t, r, "org-1,ws-1,wt-1"),
+		map[string]string{"organization_id": "org-1", "workspace_id": "ws-1", "id": "wt-1"})
+}
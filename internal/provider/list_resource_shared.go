@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ list.ListResourceWithConfigure = &entityListResource{}
+
+// entityListResource lists the entities of a collection for terraform query.
+// The parents are the ids the collection is nested under, they are both the
+// configuration of the list and, together with the id, the identity of every
+// result. Full resources are read with the Read of the managed resource.
+type entityListResource struct {
+	client   *http.Client
+	endpoint string
+	token    string
+
+	managed     resource.Resource
+	description string
+	parents     []string
+	collection  string
+	entity      any
+	displayName func(entity any) string
+	include     func(entity any) bool
+}
+
+func (l *entityListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	l.managed.Metadata(ctx, req, resp)
+}
+
+func (l *entityListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	attributes := map[string]listschema.Attribute{}
+	for _, name := range l.parents {
+		attributes[name] = listschema.StringAttribute{
+			Required:    true,
+			Description: identityDescriptions[name],
+		}
+	}
+
+	resp.Schema = listschema.Schema{
+		Description: l.description,
+		Attributes:  attributes,
+	}
+}
+
+func (l *entityListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*TerrakubeConnectionData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *TerrakubeConnectionData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	if providerData.InsecureHttpClient {
+		if custom, ok := http.DefaultTransport.(*http.Transport); ok {
+			customTransport := custom.Clone()
+			customTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+			l.client = &http.Client{Transport: customTransport}
+		} else {
+			l.client = &http.Client{}
+		}
+	} else {
+		l.client = &http.Client{}
+	}
+
+	l.endpoint = providerData.Endpoint
+	l.token = providerData.Token
+
+	if managed, ok := l.managed.(resource.ResourceWithConfigure); ok {
+		managed.Configure(ctx, req, resp)
+	}
+
+	tflog.Debug(ctx, "Configuring list resource", map[string]any{"success": true})
+}
+
+func (l *entityListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var parentIDs []any
+	for _, name := range l.parents {
+		var value types.String
+		diags := req.Config.GetAttribute(ctx, path.Root(name), &value)
+		if diags.HasError() {
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+		parentIDs = append(parentIDs, value.ValueString())
+	}
+
+	api := importAPI{client: l.client, endpoint: l.endpoint, token: l.token}
+	entities, err := api.listEntities(ctx, fmt.Sprintf(l.collection, parentIDs...), "", "", l.entity)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Error executing list request", fmt.Sprintf("Error executing list request: %s", err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for _, entity := range entities {
+			if l.include != nil && !l.include(entity) {
+				continue
+			}
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+			count++
+
+			if !push(l.result(ctx, req, parentIDs, entity)) {
+				return
+			}
+		}
+	}
+}
+
+func (l *entityListResource) result(ctx context.Context, req list.ListRequest, parentIDs []any, entity any) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = l.displayName(entity)
+
+	state := tfsdk.State{
+		Schema: req.ResourceSchema,
+		Raw:    tftypes.NewValue(req.ResourceSchema.Type().TerraformType(ctx), nil),
+	}
+	for i, name := range l.parents {
+		result.Diagnostics.Append(result.Identity.SetAttribute(ctx, path.Root(name), parentIDs[i])...)
+		result.Diagnostics.Append(state.SetAttribute(ctx, path.Root(name), parentIDs[i])...)
+	}
+	result.Diagnostics.Append(result.Identity.SetAttribute(ctx, path.Root("id"), entityID(entity))...)
+	result.Diagnostics.Append(state.SetAttribute(ctx, path.Root("id"), entityID(entity))...)
+
+	if !req.IncludeResource || result.Diagnostics.HasError() {
+		return result
+	}
+
+	readResp := &resource.ReadResponse{State: state}
+	l.managed.Read(ctx, resource.ReadRequest{State: state}, readResp)
+	result.Diagnostics.Append(readResp.Diagnostics...)
+	result.Resource.Raw = readResp.State.Raw
+	return result
+}
+
+func entityName(entity any) string {
+	return entityField(entity, "Name")
+}
@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func testListResults(t *testing.T, l *entityListResource, endpoint string, config map[string]string, includeResource bool) []list.ListResult {
+	t.Helper()
+	ctx := context.Background()
+
+	if l.client == nil {
+		l.client, l.endpoint, l.token = http.DefaultClient, endpoint, "token"
+	}
+
+	configResp := &list.ListResourceSchemaResponse{}
+	l.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, configResp)
+	configValues := map[string]tftypes.Value{}
+	for name, value := range config {
+		configValues[name] = tftypes.NewValue(tftypes.String, value)
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	l.managed.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	identityResp := &resource.IdentitySchemaResponse{}
+	l.managed.(resource.ResourceWithIdentity).IdentitySchema(ctx, resource.IdentitySchemaRequest{}, identityResp)
+
+	stream := &list.ListResultsStream{}
+	l.List(ctx, list.ListRequest{
+		Config: tfsdk.Config{
+			Schema: configResp.Schema,
+			Raw:    tftypes.NewValue(configResp.Schema.Type().TerraformType(ctx), configValues),
+		},
+		IncludeResource:        includeResource,
+		ResourceSchema:         schemaResp.Schema,
+		ResourceIdentitySchema: identityResp.IdentitySchema,
+	}, stream)
+
+	var results []list.ListResult
+	for result := range stream.Results {
+		if result.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", result.Diagnostics)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func TestWorkspaceCliListResource(t *testing.T) {
+	ctx := context.Background()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/organization/org-1/workspace", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[
+			{"type":"workspace","id":"ws-1","attributes":{"name":"network","source":"empty"}},
+			{"type":"workspace","id":"ws-2","attributes":{"name":"dns","source":"https://github.com/acme/dns.git"}},
+			{"type":"workspace","id":"ws-3","attributes":{"name":"old","source":"empty","deleted":true}}
+		]}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	results := testListResults(t, NewWorkspaceCliListResource().(*entityListResource), server.URL, map[string]string{"organization_id": "org-1"}, false)
+	if len(results) != 1 || results[0].DisplayName != "network" {
+		t.Fatalf("expected only the active CLI workspace, got %+v", results)
+	}
+
+	var id types.String
+	results[0].Identity.GetAttribute(ctx, path.Root("id"), &id)
+	if id.ValueString() != "ws-1" {
+		t.Errorf("expected the workspace id in the identity, got %s", id)
+	}
+	if !results[0].Resource.Raw.IsNull() {
+		t.Errorf("expected no resource when it isn't requested, got %s", results[0].Resource.Raw)
+	}
+}
+
+func TestTeamListResource_IncludeResource(t *testing.T) {
+	ctx := context.Background()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/organization/org-1/team", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[{"type":"team","id":"team-1","attributes":{"name":"OPS"}}]}`))
+	})
+	mux.HandleFunc("/api/v1/organization/org-1/team/team-1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"type":"team","id":"team-1","attributes":{"name":"OPS","manageState":true}}}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	l := NewTeamListResource().(*entityListResource)
+	l.managed.(*TeamResource).client, l.managed.(*TeamResource).endpoint = http.DefaultClient, server.URL
+
+	results := testListResults(t, l, server.URL, map[string]string{"organization_id": "org-1"}, true)
+	if len(results) != 1 {
+		t.Fatalf("expected one team, got %+v", results)
+	}
+
+	var name types.String
+	var manageState types.Bool
+	results[0].Resource.GetAttribute(ctx, path.Root("name"), &name)
+	results[0].Resource.GetAttribute(ctx, path.Root("manage_state"), &manageState)
+	if name.ValueString() != "OPS" || !manageState.ValueBool() {
+		t.Errorf("expected the team to be read into the resource, got %s", results[0].Resource.Raw)
+	}
+}
+
+// TestListResource_ConfiguredByProvider covers list resources getting their
+// API client from the provider, rather than the one testListResults sets.
+func TestListResource_ConfiguredByProvider(t *testing.T) {
+	ctx := context.Background()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/organization/org-1/team", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer provider-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"type":"team","id":"team-1","attributes":{"name":"OPS"}}]}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	p := &TerrakubeProvider{}
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+	configResp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"endpoint":             tftypes.NewValue(tftypes.String, server.URL),
+			"token":                tftypes.NewValue(tftypes.String, "provider-token"),
+			"insecure_http_client": tftypes.NewValue(tftypes.Bool, nil),
+		}),
+	}}, configResp)
+	if configResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics configuring the provider: %v", configResp.Diagnostics)
+	}
+
+	l := NewTeamListResource().(*entityListResource)
+	listConfigureResp := &resource.ConfigureResponse{}
+	l.Configure(ctx, resource.ConfigureRequest{ProviderData: configResp.ListResourceData}, listConfigureResp)
+	if listConfigureResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics configuring the list resource: %v", listConfigureResp.Diagnostics)
+	}
+
+	results := testListResults(t, l, "", map[string]string{"organization_id": "org-1"}, false)
+	if len(results) != 1 || results[0].DisplayName != "OPS" {
+		t.Fatalf("expected the team listed with the provider's endpoint and token, got %+v", results)
+	}
+}
+
+func TestEntityListResource_FollowsPages(t *testing.T) {
+	const total = 2*listPageSize + 1
+
+	var pages []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/organization/org-1/team", func(w http.ResponseWriter, r *http.Request) {
+		number, _ := strconv.Atoi(r.URL.Query().Get("page[number]"))
+		size, _ := strconv.Atoi(r.URL.Query().Get("page[size]"))
+		pages = append(pages, r.URL.Query().Get("page[number]"))
+
+		var data []string
+		for i := (number - 1) * size; i < min(number*size, total); i++ {
+			data = append(data, fmt.Sprintf(`{"type":"team","id":"team-%d","attributes":{"name":"team-%d"}}`, i, i))
+		}
+		fmt.Fprintf(w, `{"data":[%s]}`, strings.Join(data, ","))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	results := testListResults(t, NewTeamListResource().(*entityListResource), server.URL, map[string]string{"organization_id": "org-1"}, false)
+	if len(results) != total {
+		t.Errorf("expected %d teams from every page, got %d", total, len(results))
+	}
+	if got := strings.Join(pages, ","); got != "1,2,3" {
+		t.Errorf("expected pages 1 to 3 to be requested, got %s", got)
+	}
+	if results[len(results)-1].DisplayName != fmt.Sprintf("team-%d", total-1) {
+		t.Errorf("unexpected last team %s", results[len(results)-1].DisplayName)
+	}
+}
@@ -0,0 +1,22 @@
+package provider
+
+import (
+	"fmt"
+	"terraform-provider-terrakube/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+func NewModuleListResource() list.ListResource {
+	return &entityListResource{
+		managed:     NewModuleResource(),
+		description: "List the modules of an organization.",
+		parents:     []string{"organization_id"},
+		collection:  "organization/%s/module",
+		entity:      new(client.ModuleEntity),
+		displayName: func(entity any) string {
+			module := entity.(*client.ModuleEntity)
+			return fmt.Sprintf("%s/%s", module.Name, module.Provider)
+		},
+	}
+}
@@ -22,6 +22,7 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ModuleResource{}
+var _ resource.ResourceWithIdentity = &ModuleResource{}
 var _ resource.ResourceWithImportState = &ModuleResource{}
 
 type ModuleResource struct {
@@ -232,9 +233,12 @@ func (r *ModuleResource) Create(ctx context.Context, req resource.CreateRequest,
 	tflog.Info(ctx, "Module Resource Created", map[string]any{"success": true})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *ModuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.Diagnostics.Append(setResourceIdentity(ctx, req.State, resp.Identity)...)
+
 	var state ModuleResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -417,6 +421,7 @@ func (r *ModuleResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *ModuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -442,6 +447,27 @@ func (r *ModuleResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 }
 
+func (r *ModuleResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema("organization_id", "id")
+}
+
 func (r *ModuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importStateFromIdentity(ctx, req, resp) {
+		return
+	}
+
+	steps := []importStep{organizationImportStep, {
+		attribute:  "id",
+		kind:       "Module",
+		collection: "organization/%s/module",
+		filter:     "module",
+		fields:     []string{"name", "provider"},
+		entity:     new(client.ModuleEntity),
+	}}
+	api := importAPI{client: r.client, endpoint: r.endpoint, token: r.token}
+	if api.importByName(ctx, req, resp, steps...) {
+		return
+	}
+
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
@@ -22,6 +22,7 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &AgentResource{}
+var _ resource.ResourceWithIdentity = &AgentResource{}
 var _ resource.ResourceWithImportState = &AgentResource{}
 
 type AgentResource struct {
@@ -177,9 +178,12 @@ func (r *AgentResource) Create(ctx context.Context, req resource.CreateRequest,
 	tflog.Info(ctx, "Module Resource Created", map[string]any{"success": true})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *AgentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.Diagnostics.Append(setResourceIdentity(ctx, req.State, resp.Identity)...)
+
 	var state AgentResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -312,6 +316,7 @@ func (r *AgentResource) Update(ctx context.Context, req resource.UpdateRequest,
 	plan.Url = types.StringValue(module.Url)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *AgentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -337,13 +342,34 @@ func (r *AgentResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 }
 
+func (r *AgentResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema("organization_id", "id")
+}
+
 func (r *AgentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importStateFromIdentity(ctx, req, resp) {
+		return
+	}
+
+	steps := []importStep{organizationImportStep, {
+		attribute:  "id",
+		kind:       "Agent",
+		collection: "organization/%s/agent",
+		filter:     "agent",
+		fields:     []string{"name"},
+		entity:     new(client.AgentEntity),
+	}}
+	api := importAPI{client: r.client, endpoint: r.endpoint, token: r.token}
+	if api.importByName(ctx, req, resp, steps...) {
+		return
+	}
+
 	idParts := strings.Split(req.ID, ",")
 
 	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: 'organization_ID,ID', Got: %q", req.ID),
+			fmt.Sprintf("Expected import identifier with format: 'organization_ID,ID' or '%s', Got: %q", importNamesFormat(steps...), req.ID),
 		)
 		return
 	}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// parentLocks serializes changes to a parent object, like a webhook and its
+// events, across every resource of the provider. Terraform applies resources
+// in parallel, so resources that read and then modify a parent lock it first
+// to not lose each other's updates.
+var parentLocks = newMutexKV()
+
+// mutexKV is a mutex per key, created on first use.
+type mutexKV struct {
+	lock  sync.Mutex
+	store map[string]*sync.Mutex
+}
+
+func newMutexKV() *mutexKV {
+	return &mutexKV{store: map[string]*sync.Mutex{}}
+}
+
+// Lock locks the mutex of the key, waiting until it is available.
+func (m *mutexKV) Lock(key string) {
+	m.get(key).Lock()
+}
+
+// Unlock unlocks the mutex of the key.
+func (m *mutexKV) Unlock(key string) {
+	m.get(key).Unlock()
+}
+
+func (m *mutexKV) get(key string) *sync.Mutex {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	mutex, ok := m.store[key]
+	if !ok {
+		mutex = &sync.Mutex{}
+		m.store[key] = mutex
+	}
+	return mutex
+}
+
+// lockParent locks the parent object of the given kind and id, the returned
+// function unlocks it.
+func lockParent(ctx context.Context, kind, id string) func() {
+	key := fmt.Sprintf("%s/%s", kind, id)
+	tflog.Debug(ctx, "Locking parent object", map[string]any{"key": key})
+	parentLocks.Lock(key)
+	return func() {
+		parentLocks.Unlock(key)
+		tflog.Debug(ctx, "Unlocked parent object", map[string]any{"key": key})
+	}
+}
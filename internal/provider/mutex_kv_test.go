@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMutexKV_SerializesSameKey(t *testing.T) {
+	ctx := context.Background()
+
+	// A read-modify-write with a pause in between loses updates unless the
+	// writers are serialized.
+	var wg sync.WaitGroup
+	counter := 0
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer lockParent(ctx, "webhook", "wh-1")()
+
+			value := counter
+			time.Sleep(time.Millisecond)
+			counter = value + 1
+		}()
+	}
+	wg.Wait()
+
+	if counter != 20 {
+		t.Errorf("expected 20 serialized updates, got %d", counter)
+	}
+}
+
+func TestMutexKV_IndependentKeys(t *testing.T) {
+	m := newMutexKV()
+	m.Lock("webhook/wh-1")
+	defer m.Unlock("webhook/wh-1")
+
+	locked := make(chan struct{})
+	go func() {
+		m.Lock("webhook/wh-2")
+		m.Unlock("webhook/wh-2")
+		close(locked)
+	}()
+
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		t.Fatal("locking another key blocked on wh-1")
+	}
+}
@@ -108,7 +108,7 @@ func (d *NotificationConfigurationDataSource) Schema(_ context.Context, _ dataso
 			},
 			"channel_type": schema.StringAttribute{
 				Computed:    true,
-				Description: "Delivery channel: `SLACK`, `TEAMS`, or `WEBHOOK`.",
+				Description: "Delivery channel: `SLACK`, `TEAMS`, `TEAMS_WORKFLOW`, `WEBHOOK`, `EMAIL` or `PAGERDUTY`.",
 			},
 			"destination_url": schema.StringAttribute{
 				Computed:    true,
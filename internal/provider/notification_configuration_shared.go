@@ -7,13 +7,22 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/mail"
 	"reflect"
+	"regexp"
+	"slices"
 	"strings"
 	"terraform-provider-terrakube/internal/client"
+	"time"
 
 	"github.com/google/jsonapi"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/terrakube-io/terraform-provider-terrakube/notify"
 )
 
 // notificationConfigAPI bundles the HTTP client/endpoint/token every notification-related
@@ -35,6 +44,327 @@ func optionalStringValue(s *string) types.String {
 	return types.StringValue(*s)
 }
 
+// notificationChannelTypes are the delivery channels a notification configuration can use.
+var notificationChannelTypes = []string{"SLACK", "TEAMS", "TEAMS_WORKFLOW", "WEBHOOK", "EMAIL", "PAGERDUTY"}
+
+// notificationTemplatePlaceholder matches the {{field}} placeholders of a WEBHOOK body template.
+var notificationTemplatePlaceholder = regexp.MustCompile(`{{\s*([^{}]*?)\s*}}`)
+
+// notificationHeaderName matches a valid HTTP header name (RFC 9110 token).
+var notificationHeaderName = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+
+// notificationReservedHeaders are set by Terrakube on every WEBHOOK request and can't be
+// overridden by the headers of a configuration.
+var notificationReservedHeaders = []string{"Content-Length", "Content-Type", "Host", notify.SignatureHeader}
+
+// notificationChannelConfig holds the attributes of a notification configuration that depend on
+// its channel type, shared by the workspace and organization resources.
+type notificationChannelConfig struct {
+	ChannelType    types.String
+	DestinationUrl types.String
+	BodyTemplate   types.String
+	Headers        types.Map
+	RoutingKey     types.String
+}
+
+// notificationTemplateVariables returns the fields a WEBHOOK body template can reference, the
+// fields of the notification payload.
+func notificationTemplateVariables() []string {
+	payload := reflect.TypeOf(notify.Payload{})
+	variables := make([]string, 0, payload.NumField())
+	for i := range payload.NumField() {
+		variables = append(variables, strings.Split(payload.Field(i).Tag.Get("json"), ",")[0])
+	}
+	return variables
+}
+
+// validateNotificationChannel checks the attributes that only apply to some channel types: an
+// EMAIL destination is a mailto: URL, PAGERDUTY needs a routing key, and only WEBHOOK takes a
+// body template and headers.
+func validateNotificationChannel(ctx context.Context, config notificationChannelConfig) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if config.ChannelType.IsUnknown() || config.ChannelType.IsNull() {
+		return diags
+	}
+	channelType := config.ChannelType.ValueString()
+
+	if channelType == "EMAIL" && !config.DestinationUrl.IsUnknown() && !config.DestinationUrl.IsNull() {
+		destination := config.DestinationUrl.ValueString()
+		addresses, ok := strings.CutPrefix(destination, "mailto:")
+		if _, err := mail.ParseAddressList(addresses); !ok || err != nil {
+			diags.AddAttributeError(path.Root("destination_url"), "Invalid Email Destination",
+				fmt.Sprintf("EMAIL destinations are mailto: URLs with comma separated addresses, for example mailto:ops@example.com,sre@example.com, got %q.", destination))
+		}
+	}
+
+	if channelType == "PAGERDUTY" && config.RoutingKey.IsNull() {
+		diags.AddAttributeError(path.Root("routing_key"), "Missing PagerDuty Routing Key",
+			"PAGERDUTY notification configurations need the routing_key of the PagerDuty service integration.")
+	}
+	if channelType != "PAGERDUTY" && !config.RoutingKey.IsNull() {
+		diags.AddAttributeError(path.Root("routing_key"), "Invalid Notification Attribute",
+			fmt.Sprintf("routing_key only applies to PAGERDUTY notification configurations, this one is %s.", channelType))
+	}
+
+	for name, value := range map[string]attr.Value{"body_template": config.BodyTemplate, "headers": config.Headers} {
+		if channelType != "WEBHOOK" && !value.IsNull() {
+			diags.AddAttributeError(path.Root(name), "Invalid Notification Attribute",
+				fmt.Sprintf("%s only applies to WEBHOOK notification configurations, this one is %s.", name, channelType))
+		}
+	}
+
+	if !config.BodyTemplate.IsUnknown() && !config.BodyTemplate.IsNull() {
+		variables := notificationTemplateVariables()
+		for _, match := range notificationTemplatePlaceholder.FindAllStringSubmatch(config.BodyTemplate.ValueString(), -1) {
+			if !slices.Contains(variables, match[1]) {
+				diags.AddAttributeError(path.Root("body_template"), "Invalid Body Template",
+					fmt.Sprintf("Unknown placeholder %s, body templates can reference: %s.", match[0], strings.Join(variables, ", ")))
+			}
+		}
+	}
+
+	if !config.Headers.IsUnknown() && !config.Headers.IsNull() {
+		for name := range config.Headers.Elements() {
+			if !notificationHeaderName.MatchString(name) {
+				diags.AddAttributeError(path.Root("headers").AtMapKey(name), "Invalid Header Name", fmt.Sprintf("%q is not a valid HTTP header name.", name))
+			}
+			if slices.ContainsFunc(notificationReservedHeaders, func(reserved string) bool { return strings.EqualFold(reserved, name) }) {
+				diags.AddAttributeError(path.Root("headers").AtMapKey(name), "Invalid Header Name", fmt.Sprintf("%s is set by Terrakube and can't be overridden.", name))
+			}
+		}
+	}
+
+	return diags
+}
+
+// notificationHeaders converts the headers of a configuration to the attribute sent to the API.
+// The attribute is omitted when null, so headers removed from prior are sent as empty instead.
+func notificationHeaders(ctx context.Context, headers, prior types.Map) (map[string]interface{}, diag.Diagnostics) {
+	if headers.IsNull() || headers.IsUnknown() {
+		if !prior.IsNull() && !prior.IsUnknown() {
+			return map[string]interface{}{}, nil
+		}
+		return nil, nil
+	}
+
+	var values map[string]string
+	diags := headers.ElementsAs(ctx, &values, false)
+	result := make(map[string]interface{}, len(values))
+	for name, value := range values {
+		result[name] = value
+	}
+	return result, diags
+}
+
+// notificationHeadersValue converts the headers returned by the API, null when there are none
+// like unset headers.
+func notificationHeadersValue(ctx context.Context, headers map[string]interface{}) (types.Map, diag.Diagnostics) {
+	if len(headers) == 0 {
+		return types.MapNull(types.StringType), nil
+	}
+
+	values := make(map[string]string, len(headers))
+	for name, value := range headers {
+		values[name] = fmt.Sprint(value)
+	}
+	return types.MapValueFrom(ctx, types.StringType, values)
+}
+
+// notificationSecretGeneratedKey is the private state key recording that the
+// signing secret in state was generated by the provider rather than configured.
+const notificationSecretGeneratedKey = "signing_secret_generated"
+
+// resolveSigningSecret returns the signing secret to send when creating a configuration whose
+// signing_secret is unknown because it was omitted: a random secret for WEBHOOK channels, so
+// receivers can always verify notifications, and none for the other channels, which don't sign.
+func resolveSigningSecret(channelType string, planned types.String) (types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if !planned.IsUnknown() {
+		return planned, diags
+	}
+	if channelType != "WEBHOOK" {
+		return types.StringNull(), diags
+	}
+
+	secret, err := notify.NewSecret()
+	if err != nil {
+		diags.AddError("Error generating signing secret", fmt.Sprintf("Error generating signing secret: %s", err))
+		return planned, diags
+	}
+	return types.StringValue(secret), diags
+}
+
+// plannedSigningSecret returns the signing secret of an existing configuration whose
+// signing_secret is omitted. Secrets are only generated on create, so a generated secret is
+// kept, while a secret that was configured and then removed, or the secret of a channel that
+// is no longer WEBHOOK, is cleared.
+func plannedSigningSecret(channelType, prior types.String, generated bool) types.String {
+	if channelType.IsUnknown() {
+		return types.StringUnknown()
+	}
+	if channelType.ValueString() != "WEBHOOK" || !generated {
+		return types.StringNull()
+	}
+	return prior
+}
+
+// clearableStringRequestValue returns an optional attribute to send in an update, like the
+// signing secret, body template or routing key. The attribute is omitted when null, so a
+// cleared value is sent as empty instead.
+func clearableStringRequestValue(planned, prior types.String) *string {
+	if planned.IsNull() && !prior.IsNull() {
+		empty := ""
+		return &empty
+	}
+	return planned.ValueStringPointer()
+}
+
+// clearableStringValue reads an attribute sent by clearableStringRequestValue, a cleared
+// value reads back empty and is null like an omitted one.
+func clearableStringValue(value *string) types.String {
+	if value == nil || *value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(*value)
+}
+
+// modifySigningSecretPlan plans signing_secret, which is computed only when omitted.
+func modifySigningSecretPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var configured, channelType, prior types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("signing_secret"), &configured)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("channel_type"), &channelType)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("signing_secret"), &prior)...)
+	generated, diags := req.Private.GetKey(ctx, notificationSecretGeneratedKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !configured.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("signing_secret"), plannedSigningSecret(channelType, prior, generated != nil))...)
+}
+
+// notificationTestClient sends test notifications. They go to the destination of a configuration
+// instead of Terrakube, so the insecure_http_client setting of the provider doesn't apply.
+var notificationTestClient = &http.Client{Timeout: 30 * time.Second}
+
+// notificationTestPayload returns the payload of the test notification of a configuration.
+func notificationTestPayload(organizationID, workspaceID string) notify.Payload {
+	return notify.Payload{
+		OrganizationID: organizationID,
+		WorkspaceID:    workspaceID,
+		JobStatus:      "test",
+		TriggeredBy:    "terraform",
+		Timestamp:      time.Now().UTC(),
+	}
+}
+
+// notificationTestBody returns the body of the test notification of a channel: a text message
+// for Slack and Teams, an Adaptive Card message for Teams Workflows, and for WEBHOOK the payload,
+// or the body template with its placeholders replaced by the fields of the payload. String fields
+// are JSON escaped since the placeholders sit inside the string literals of the template.
+func notificationTestBody(channelType, name string, bodyTemplate types.String, payload notify.Payload) ([]byte, error) {
+	text := fmt.Sprintf("Test notification of the Terrakube notification configuration %q, sent by Terraform when the configuration changed.", name)
+	switch channelType {
+	case "SLACK", "TEAMS":
+		return json.Marshal(map[string]string{"text": text})
+	case "TEAMS_WORKFLOW":
+		return json.Marshal(map[string]any{
+			"type": "message",
+			"attachments": []map[string]any{{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]any{
+					"type":    "AdaptiveCard",
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"version": "1.4",
+					"body":    []map[string]any{{"type": "TextBlock", "text": text, "wrap": true}},
+				},
+			}},
+		})
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil || bodyTemplate.IsNull() {
+		return body, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var fields map[string]any
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, err
+	}
+	rendered := notificationTemplatePlaceholder.ReplaceAllStringFunc(bodyTemplate.ValueString(), func(placeholder string) string {
+		value := fields[notificationTemplatePlaceholder.FindStringSubmatch(placeholder)[1]]
+		if text, ok := value.(string); ok {
+			escaped, _ := json.Marshal(text)
+			return string(escaped[1 : len(escaped)-1])
+		}
+		return fmt.Sprint(value)
+	})
+	return []byte(rendered), nil
+}
+
+// sendTestNotification delivers a test notification to the destination of a configuration the
+// way its channel is delivered, so a broken destination URL fails the apply instead of going
+// unnoticed until a job triggers the configuration. EMAIL and PAGERDUTY are only warned about,
+// the provider can't send mail and a PagerDuty event would open an incident.
+func sendTestNotification(ctx context.Context, name string, channel notificationChannelConfig, signingSecret types.String, payload notify.Payload) diag.Diagnostics {
+	var diags diag.Diagnostics
+	channelType := channel.ChannelType.ValueString()
+	if channelType == "EMAIL" || channelType == "PAGERDUTY" {
+		diags.AddAttributeWarning(path.Root("send_test_on_change"), "Test Notification Not Sent",
+			fmt.Sprintf("The provider can't send test notifications through %s channels, the destination is only used when a job triggers the configuration.", channelType))
+		return diags
+	}
+
+	body, err := notificationTestBody(channelType, name, channel.BodyTemplate, payload)
+	if err != nil {
+		diags.AddError("Error building test notification", fmt.Sprintf("Error building test notification: %s", err))
+		return diags
+	}
+
+	destination := channel.DestinationUrl.ValueString()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, destination, bytes.NewReader(body))
+	if err != nil {
+		diags.AddError("Error creating test notification request", fmt.Sprintf("Error creating test notification request: %s", err))
+		return diags
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if channelType == "WEBHOOK" {
+		var headers map[string]string
+		if !channel.Headers.IsNull() {
+			diags.Append(channel.Headers.ElementsAs(ctx, &headers, false)...)
+		}
+		for header, value := range headers {
+			request.Header.Set(header, value)
+		}
+		if signingSecret.ValueString() != "" {
+			request.Header.Set(notify.SignatureHeader, notify.Sign(signingSecret.ValueString(), body))
+		}
+	}
+
+	response, err := notificationTestClient.Do(request)
+	if err != nil {
+		diags.AddAttributeError(path.Root("destination_url"), "Test Notification Failed",
+			fmt.Sprintf("The test notification could not be delivered to %s: %s", destination, err))
+		return diags
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		responseBody, _ := io.ReadAll(io.LimitReader(response.Body, 4096))
+		diags.AddAttributeError(path.Root("destination_url"), "Test Notification Failed",
+			fmt.Sprintf("%s rejected the test notification with status %s: %s", destination, response.Status, responseBody))
+		return diags
+	}
+
+	tflog.Info(ctx, "Test notification delivered", map[string]any{"status_code": response.StatusCode})
+	return diags
+}
+
 // fetchNotificationTriggers reads the full set of trigger resources attached to a notification
 // configuration via the related-resource collection endpoint, which - unlike the relationship
 // linkage embedded in the configuration's own GET response - returns each trigger's full
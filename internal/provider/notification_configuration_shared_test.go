@@ -2,13 +2,19 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	"terraform-provider-terrakube/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terrakube-io/terraform-provider-terrakube/notify"
 )
 
 func TestSyncNotificationTriggers_AddsAndRemoves(t *testing.T) {
@@ -205,3 +211,245 @@ func indexOf(haystack, needle string) int {
 	}
 	return -1
 }
+
+func TestResolveSigningSecret(t *testing.T) {
+	if secret, _ := resolveSigningSecret("WEBHOOK", types.StringValue("configured")); secret.ValueString() != "configured" {
+		t.Errorf("expected a configured secret to be kept, got %s", secret)
+	}
+	if secret, _ := resolveSigningSecret("SLACK", types.StringUnknown()); !secret.IsNull() {
+		t.Errorf("expected no secret for SLACK, got %s", secret)
+	}
+	if secret, _ := resolveSigningSecret("WEBHOOK", types.StringUnknown()); len(secret.ValueString()) != 64 {
+		t.Errorf("expected a generated secret for WEBHOOK, got %s", secret)
+	}
+}
+
+// withPrivateState gives a Create or Update response the empty private state the framework
+// initializes it with, whose type is internal to the framework.
+func withPrivateState(resp any) {
+	field := reflect.ValueOf(resp).Elem().FieldByName("Private")
+	field.Set(reflect.New(field.Type().Elem()))
+}
+
+func TestPlannedSigningSecret(t *testing.T) {
+	prior := types.StringValue("generated")
+	tests := []struct {
+		name        string
+		channelType types.String
+		generated   bool
+		want        types.String
+	}{
+		{name: "generated webhook secret", channelType: types.StringValue("WEBHOOK"), generated: true, want: prior},
+		{name: "configured secret removed", channelType: types.StringValue("WEBHOOK"), want: types.StringNull()},
+		{name: "channel no longer webhook", channelType: types.StringValue("SLACK"), generated: true, want: types.StringNull()},
+		{name: "unknown channel", channelType: types.StringUnknown(), generated: true, want: types.StringUnknown()},
+	}
+
+	for _, test := range tests {
+		if got := plannedSigningSecret(test.channelType, prior, test.generated); !got.Equal(test.want) {
+			t.Errorf("%s: expected %s, got %s", test.name, test.want, got)
+		}
+	}
+}
+
+func TestClearableStringRequestValue(t *testing.T) {
+	if got := clearableStringRequestValue(types.StringNull(), types.StringValue("old")); got == nil || *got != "" {
+		t.Errorf("expected a cleared secret to be sent empty, got %v", got)
+	}
+	if got := clearableStringRequestValue(types.StringNull(), types.StringNull()); got != nil {
+		t.Errorf("expected no secret to be omitted, got %q", *got)
+	}
+	if got := clearableStringRequestValue(types.StringValue("new"), types.StringValue("old")); got == nil || *got != "new" {
+		t.Errorf("expected the planned secret to be sent, got %v", got)
+	}
+	empty := ""
+	if got := clearableStringValue(&empty); !got.IsNull() {
+		t.Errorf("expected a cleared secret to read back null, got %s", got)
+	}
+}
+
+func TestNotificationHeaders_ClearsRemovedHeaders(t *testing.T) {
+	ctx := context.Background()
+	prior := types.MapValueMust(types.StringType, map[string]attr.Value{"X-Team": types.StringValue("platform")})
+
+	headers, diags := notificationHeaders(ctx, types.MapNull(types.StringType), prior)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if headers == nil || len(headers) != 0 {
+		t.Errorf("expected removed headers to be sent empty, got %v", headers)
+	}
+	if headers, _ := notificationHeaders(ctx, types.MapNull(types.StringType), types.MapNull(types.StringType)); headers != nil {
+		t.Errorf("expected no headers to be omitted, got %v", headers)
+	}
+}
+
+func TestValidateNotificationChannel(t *testing.T) {
+	ctx := context.Background()
+	headers := func(values map[string]string) types.Map {
+		m, _ := types.MapValueFrom(ctx, types.StringType, values)
+		return m
+	}
+	config := func(channelType, destination string) notificationChannelConfig {
+		return notificationChannelConfig{
+			ChannelType:    types.StringValue(channelType),
+			DestinationUrl: types.StringValue(destination),
+			BodyTemplate:   types.StringNull(),
+			Headers:        types.MapNull(types.StringType),
+			RoutingKey:     types.StringNull(),
+		}
+	}
+
+	templated := config("WEBHOOK", "https://incidents.example.com")
+	templated.BodyTemplate = types.StringValue(`{"summary":"{{ workspace }} is {{jobStatus}}","link":"{{url}}"}`)
+	templated.Headers = headers(map[string]string{"Authorization": "Bearer token"})
+
+	unknownPlaceholder := config("WEBHOOK", "https://incidents.example.com")
+	unknownPlaceholder.BodyTemplate = types.StringValue(`{"summary":"{{status}}"}`)
+
+	reservedHeader := config("WEBHOOK", "https://incidents.example.com")
+	reservedHeader.Headers = headers(map[string]string{"x-terrakube-signature": "forged"})
+
+	slackTemplate := config("SLACK", "https://hooks.slack.com/x")
+	slackTemplate.BodyTemplate = types.StringValue("{{jobStatus}}")
+
+	pagerDuty := config("PAGERDUTY", "https://events.pagerduty.com/v2/enqueue")
+	pagerDuty.RoutingKey = types.StringValue("routing-key")
+
+	teamsRoutingKey := config("TEAMS_WORKFLOW", "https://prod.workflows.example.com/trigger")
+	teamsRoutingKey.RoutingKey = types.StringValue("routing-key")
+
+	tests := []struct {
+		name   string
+		config notificationChannelConfig
+		valid  bool
+	}{
+		{name: "templated webhook", config: templated, valid: true},
+		{name: "email", config: config("EMAIL", "mailto:ops@example.com,SRE <sre@example.com>"), valid: true},
+		{name: "pagerduty", config: pagerDuty, valid: true},
+		{name: "unknown placeholder", config: unknownPlaceholder},
+		{name: "reserved header", config: reservedHeader},
+		{name: "template on slack", config: slackTemplate},
+		{name: "email without mailto", config: config("EMAIL", "ops@example.com")},
+		{name: "pagerduty without routing key", config: config("PAGERDUTY", "https://events.pagerduty.com/v2/enqueue")},
+		{name: "routing key on teams", config: teamsRoutingKey},
+	}
+
+	for _, test := range tests {
+		if diags := validateNotificationChannel(ctx, test.config); diags.HasError() == test.valid {
+			t.Errorf("%s: expected valid=%t, got %v", test.name, test.valid, diags)
+		}
+	}
+}
+
+func webhookTestChannel(ctx context.Context, destination string) notificationChannelConfig {
+	headers, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{"Authorization": "Bearer incident-token"})
+	return notificationChannelConfig{
+		ChannelType:    types.StringValue("WEBHOOK"),
+		DestinationUrl: types.StringValue(destination),
+		BodyTemplate:   types.StringNull(),
+		Headers:        headers,
+		RoutingKey:     types.StringNull(),
+	}
+}
+
+func TestSendTestNotification_SignedWebhook(t *testing.T) {
+	ctx := context.Background()
+	var payload *notify.Payload
+	var authorization string
+	server := httptest.NewServer(notify.Handler("secret", func(w http.ResponseWriter, r *http.Request, p *notify.Payload) {
+		payload = p
+		authorization = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	diags := sendTestNotification(ctx, "receiver", webhookTestChannel(ctx, server.URL), types.StringValue("secret"), notificationTestPayload("org-1", "ws-1"))
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if payload == nil || payload.OrganizationID != "org-1" || payload.WorkspaceID != "ws-1" || payload.JobStatus != "test" {
+		t.Fatalf("expected a verified test payload, got %+v", payload)
+	}
+	if authorization != "Bearer incident-token" {
+		t.Errorf("expected the configured headers to be sent, got Authorization %q", authorization)
+	}
+}
+
+func TestSendTestNotification_BodyTemplate(t *testing.T) {
+	ctx := context.Background()
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+	}))
+	defer server.Close()
+
+	channel := webhookTestChannel(ctx, server.URL)
+	channel.BodyTemplate = types.StringValue(`{"summary":"{{ workspaceId }} is {{jobStatus}}","job":{{jobId}}}`)
+	if diags := sendTestNotification(ctx, "receiver", channel, types.StringNull(), notificationTestPayload("org-1", "ws-1")); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if body != `{"summary":"ws-1 is test","job":0}` {
+		t.Errorf("unexpected rendered body %s", body)
+	}
+}
+
+func TestNotificationTestBody_EscapesPlaceholders(t *testing.T) {
+	payload := notificationTestPayload("org-1", `ws "1" \ tab	`)
+	body, err := notificationTestBody("WEBHOOK", "receiver", types.StringValue(`{"workspace":"{{workspaceId}}","job":{{jobId}}}`), payload)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var rendered map[string]any
+	if err := json.Unmarshal(body, &rendered); err != nil {
+		t.Fatalf("expected valid JSON, got %s: %v", body, err)
+	}
+	if rendered["workspace"] != payload.WorkspaceID {
+		t.Errorf("expected workspace %q, got %q", payload.WorkspaceID, rendered["workspace"])
+	}
+	if rendered["job"] != float64(0) {
+		t.Errorf("expected the job id to stay a number, got %v", rendered["job"])
+	}
+}
+
+func TestSendTestNotification_Channels(t *testing.T) {
+	ctx := context.Background()
+	var body string
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(status)
+		fmt.Fprint(w, "no_service")
+	}))
+	defer server.Close()
+
+	channel := func(channelType string) notificationChannelConfig {
+		return notificationChannelConfig{ChannelType: types.StringValue(channelType), DestinationUrl: types.StringValue(server.URL)}
+	}
+	payload := notificationTestPayload("org-1", "")
+
+	if diags := sendTestNotification(ctx, "alerts", channel("SLACK"), types.StringNull(), payload); diags.HasError() || !contains(body, `{"text":"Test notification`) {
+		t.Errorf("expected a Slack text message, got %s (%v)", body, diags)
+	}
+	if diags := sendTestNotification(ctx, "alerts", channel("TEAMS_WORKFLOW"), types.StringNull(), payload); diags.HasError() || !contains(body, `"type":"AdaptiveCard"`) {
+		t.Errorf("expected an Adaptive Card message, got %s (%v)", body, diags)
+	}
+
+	body = ""
+	diags := sendTestNotification(ctx, "alerts", channel("PAGERDUTY"), types.StringNull(), payload)
+	if diags.HasError() || diags.WarningsCount() != 1 || body != "" {
+		t.Errorf("expected only a warning for PAGERDUTY, got %v", diags)
+	}
+
+	status = http.StatusNotFound
+	diags = sendTestNotification(ctx, "alerts", channel("SLACK"), types.StringNull(), payload)
+	if !diags.HasError() || !contains(diags[0].Detail(), "404 Not Found: no_service") {
+		t.Errorf("expected the rejected test notification to be an error, got %v", diags)
+	}
+
+	server.Close()
+	if diags := sendTestNotification(ctx, "alerts", channel("SLACK"), types.StringNull(), payload); !diags.HasError() {
+		t.Errorf("expected an unreachable destination to be an error")
+	}
+}
@@ -0,0 +1,18 @@
+package provider
+
+import (
+	"terraform-provider-terrakube/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+func NewCollectionListResource() list.ListResource {
+	return &entityListResource{
+		managed:     NewCollectionResource(),
+		description: "List the collections of an organization.",
+		parents:     []string{"organization_id"},
+		collection:  "organization/%s/collection",
+		entity:      new(client.CollectionEntity),
+		displayName: entityName,
+	}
+}
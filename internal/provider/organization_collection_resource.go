@@ -24,6 +24,7 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &CollectionResource{}
+var _ resource.ResourceWithIdentity = &CollectionResource{}
 var _ resource.ResourceWithImportState = &CollectionResource{}
 
 type CollectionResource struct {
@@ -179,9 +180,12 @@ func (r *CollectionResource) Create(ctx context.Context, req resource.CreateRequ
 	tflog.Info(ctx, "Collection Resource Created", map[string]any{"success": true})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *CollectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.Diagnostics.Append(setResourceIdentity(ctx, req.State, resp.Identity)...)
+
 	var state CollectionResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -320,6 +324,7 @@ func (r *CollectionResource) Update(ctx context.Context, req resource.UpdateRequ
 	plan.Priority = types.Int32Value(collection.Priority)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *CollectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -346,13 +351,34 @@ func (r *CollectionResource) Delete(ctx context.Context, req resource.DeleteRequ
 	}
 }
 
+func (r *CollectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema("organization_id", "id")
+}
+
 func (r *CollectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importStateFromIdentity(ctx, req, resp) {
+		return
+	}
+
+	steps := []importStep{organizationImportStep, {
+		attribute:  "id",
+		kind:       "Collection",
+		collection: "organization/%s/collection",
+		filter:     "collection",
+		fields:     []string{"name"},
+		entity:     new(client.CollectionEntity),
+	}}
+	api := importAPI{client: r.client, endpoint: r.endpoint, token: r.token}
+	if api.importByName(ctx, req, resp, steps...) {
+		return
+	}
+
 	idParts := strings.Split(req.ID, ",")
 
 	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: 'organization_ID,ID', Got: %q", req.ID),
+			fmt.Sprintf("Expected import identifier with format: 'organization_ID,ID' or '%s', Got: %q", importNamesFormat(steps...), req.ID),
 		)
 		return
 	}
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/terrakube-io/terraform-provider-terrakube/notify"
 )
 
 func organizationNotificationConfigurationSchemaAndType(t *testing.T, ctx context.Context) (schema.Schema, tftypes.Object) {
@@ -132,3 +134,136 @@ func TestOrganizationNotificationConfigurationResource_Create_PostsConfigThenEac
 		t.Errorf("MessageStyle = %q, want %q", result.MessageStyle.ValueString(), "SIMPLE")
 	}
 }
+
+func TestOrganizationNotificationConfigurationResource_Create_GeneratesWebhookSigningSecret(t *testing.T) {
+	ctx := context.Background()
+	s, objType := organizationNotificationConfigurationSchemaAndType(t, ctx)
+
+	var sentSecret string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/organization/org-1/notificationConfiguration", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Data struct {
+				Attributes map[string]any `json:"attributes"`
+			} `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decoding request body: %v", err)
+		}
+		sentSecret, _ = body.Data.Attributes["signingSecret"].(string)
+		fmt.Fprintf(w, `{"data":{"type":"notification_configuration","id":"cfg-1","attributes":{
+			"name":"receiver","channelType":"WEBHOOK","destinationUrl":%q,"signingSecret":%q,"active":true,"messageStyle":"DETAILED"}}}`, body.Data.Attributes["destinationUrl"], sentSecret)
+	})
+	var testBody []byte
+	var testSignature string
+	mux.HandleFunc("/receiver", func(w http.ResponseWriter, r *http.Request) {
+		testBody, _ = io.ReadAll(r.Body)
+		testSignature = r.Header.Get(notify.SignatureHeader)
+	})
+	mux.HandleFunc("/api/v1/notification_configuration/cfg-1/triggers", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("/api/v1/notification_configuration/cfg-1/relationships/templates", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	r := &OrganizationNotificationConfigurationResource{
+		client:   server.Client(),
+		endpoint: server.URL,
+		token:    "test-token",
+	}
+
+	planValue := buildObjectValue(objType, map[string]tftypes.Value{
+		"organization_id": tftypes.NewValue(tftypes.String, "org-1"),
+		"name":            tftypes.NewValue(tftypes.String, "receiver"),
+		"channel_type":    tftypes.NewValue(tftypes.String, "WEBHOOK"),
+		"destination_url": tftypes.NewValue(tftypes.String, server.URL+"/receiver"),
+		"signing_secret":  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"active":          tftypes.NewValue(tftypes.Bool, true),
+		"message_style":   tftypes.NewValue(tftypes.String, "DETAILED"),
+		"trigger_statuses": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "failed"),
+		}),
+		"template_ids":        tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{}),
+		"send_test_on_change": tftypes.NewValue(tftypes.Bool, true),
+	})
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: s}}
+	withPrivateState(resp)
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: planValue}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if len(sentSecret) != 64 {
+		t.Fatalf("expected a generated 64 character signing secret to be sent, got %q", sentSecret)
+	}
+	var result OrganizationNotificationConfigurationResourceModel
+	if diags := resp.State.Get(ctx, &result); diags.HasError() {
+		t.Fatalf("reading resulting state: %v", diags)
+	}
+	if result.SigningSecret.ValueString() != sentSecret {
+		t.Errorf("expected the generated signing secret in state, got %q", result.SigningSecret.ValueString())
+	}
+	if generated, _ := resp.Private.GetKey(ctx, notificationSecretGeneratedKey); generated == nil {
+		t.Errorf("expected the generated signing secret to be recorded in private state")
+	}
+	if err := notify.Verify(sentSecret, testBody, testSignature); err != nil {
+		t.Errorf("expected a test notification signed with the generated secret: %v", err)
+	}
+}
+
+func TestOrganizationNotificationConfigurationResource_Create_RejectedTestCreatesNothing(t *testing.T) {
+	ctx := context.Background()
+	s, objType := organizationNotificationConfigurationSchemaAndType(t, ctx)
+
+	created := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/organization/org-1/notificationConfiguration", func(w http.ResponseWriter, r *http.Request) {
+		created = true
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("/receiver", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	r := &OrganizationNotificationConfigurationResource{
+		client:   server.Client(),
+		endpoint: server.URL,
+		token:    "test-token",
+	}
+
+	planValue := buildObjectValue(objType, map[string]tftypes.Value{
+		"organization_id": tftypes.NewValue(tftypes.String, "org-1"),
+		"name":            tftypes.NewValue(tftypes.String, "receiver"),
+		"channel_type":    tftypes.NewValue(tftypes.String, "SLACK"),
+		"destination_url": tftypes.NewValue(tftypes.String, server.URL+"/receiver"),
+		"signing_secret":  tftypes.NewValue(tftypes.String, nil),
+		"active":          tftypes.NewValue(tftypes.Bool, true),
+		"message_style":   tftypes.NewValue(tftypes.String, "DETAILED"),
+		"trigger_statuses": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "failed"),
+		}),
+		"template_ids":        tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{}),
+		"send_test_on_change": tftypes.NewValue(tftypes.Bool, true),
+	})
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: s}}
+	withPrivateState(resp)
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: planValue}}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatalf("expected the rejected test notification to fail the create")
+	}
+	if created {
+		t.Errorf("expected no configuration to be created when the test notification is rejected")
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Errorf("expected no state to be set, got %v", resp.State.Raw)
+	}
+}
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-terrakube/internal/tcl"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ basetypes.StringTypable = templateContentType{}
+var _ basetypes.StringValuableWithSemanticEquals = templateContentValue{}
+
+// templateContentType is a string holding template YAML. Values that only
+// differ in formatting, quoting, comments or key order are semantically equal,
+// so the API reformatting a template doesn't produce a diff.
+type templateContentType struct {
+	basetypes.StringType
+}
+
+func (t templateContentType) Equal(o attr.Type) bool {
+	other, ok := o.(templateContentType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t templateContentType) String() string {
+	return "templateContentType"
+}
+
+func (t templateContentType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return templateContentValue{StringValue: in}, nil
+}
+
+func (t templateContentType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+	return stringValuable, nil
+}
+
+func (t templateContentType) ValueType(ctx context.Context) attr.Value {
+	return templateContentValue{}
+}
+
+type templateContentValue struct {
+	basetypes.StringValue
+}
+
+func newTemplateContentValue(value string) templateContentValue {
+	return templateContentValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func newTemplateContentUnknown() templateContentValue {
+	return templateContentValue{StringValue: basetypes.NewStringUnknown()}
+}
+
+func (v templateContentValue) Equal(o attr.Value) bool {
+	other, ok := o.(templateContentValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v templateContentValue) Type(ctx context.Context) attr.Type {
+	return templateContentType{}
+}
+
+func (v templateContentValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(templateContentValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got value type %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	return tcl.Equivalent(v.ValueString(), newValue.ValueString()), diags
+}
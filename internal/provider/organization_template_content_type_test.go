@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestTemplateContentValue_StringSemanticEquals(t *testing.T) {
+	ctx := context.Background()
+
+	configured := newTemplateContentValue("flow:\n  - type: \"terraformPlan\"\n    name: \"Plan\"\n    step: 100\n")
+
+	tests := map[string]struct {
+		remote string
+		want   bool
+	}{
+		"identical":   {remote: configured.ValueString(), want: true},
+		"reformatted": {remote: "flow:\n- step: 100\n  name: Plan\n  type: terraformPlan\n", want: true},
+		"changed":     {remote: "flow:\n- step: 200\n  name: Plan\n  type: terraformPlan\n", want: false},
+		"unparsable":  {remote: "flow: [", want: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			equal, diags := configured.StringSemanticEquals(ctx, newTemplateContentValue(tt.remote))
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if equal != tt.want {
+				t.Errorf("got %t, want %t", equal, tt.want)
+			}
+		})
+	}
+
+	if _, diags := configured.StringSemanticEquals(ctx, types.StringValue("flow: []")); !diags.HasError() {
+		t.Error("expected comparing against another value type to fail")
+	}
+}
+
+func TestTemplateContentType_ValueFromTerraform(t *testing.T) {
+	ctx := context.Background()
+
+	value, err := templateContentType{}.ValueFromTerraform(ctx, tftypes.NewValue(tftypes.String, "flow: []"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !value.Equal(newTemplateContentValue("flow: []")) {
+		t.Errorf("expected a template content value, got %#v", value)
+	}
+}
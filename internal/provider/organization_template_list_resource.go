@@ -0,0 +1,18 @@
+package provider
+
+import (
+	"terraform-provider-terrakube/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+func NewOrganizationTemplateListResource() list.ListResource {
+	return &entityListResource{
+		managed:     NewOrganizationTemplateResource(),
+		description: "List the templates of an organization.",
+		parents:     []string{"organization_id"},
+		collection:  "organization/%s/template",
+		entity:      new(client.OrganizationTemplateEntity),
+		displayName: entityName,
+	}
+}
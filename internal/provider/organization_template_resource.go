@@ -11,10 +11,15 @@ import (
 	"strconv"
 	"strings"
 	"terraform-provider-terrakube/internal/client"
+	"terraform-provider-terrakube/internal/tcl"
 
 	"github.com/google/jsonapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -25,7 +30,10 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &OrganizationTemplateResource{}
+var _ resource.ResourceWithIdentity = &OrganizationTemplateResource{}
 var _ resource.ResourceWithImportState = &OrganizationTemplateResource{}
+var _ resource.ResourceWithModifyPlan = &OrganizationTemplateResource{}
+var _ resource.ResourceWithValidateConfig = &OrganizationTemplateResource{}
 
 type OrganizationTemplateResource struct {
 	client   *http.Client
@@ -34,12 +42,14 @@ type OrganizationTemplateResource struct {
 }
 
 type OrganizationTemplateResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	OrganizationId types.String `tfsdk:"organization_id"`
-	Name           types.String `tfsdk:"name"`
-	Description    types.String `tfsdk:"description"`
-	Version        types.String `tfsdk:"version"`
-	Content        types.String `tfsdk:"content"`
+	ID             types.String         `tfsdk:"id"`
+	OrganizationId types.String         `tfsdk:"organization_id"`
+	Name           types.String         `tfsdk:"name"`
+	Description    types.String         `tfsdk:"description"`
+	Version        types.String         `tfsdk:"version"`
+	Content        templateContentValue `tfsdk:"content"`
+	Flow           types.List           `tfsdk:"flow"`
+	FlowSteps      types.List           `tfsdk:"flow_steps"`
 }
 
 func NewOrganizationTemplateResource() resource.Resource {
@@ -80,8 +90,103 @@ func (r *OrganizationTemplateResource) Schema(ctx context.Context, req resource.
 				Description: "The version of the template",
 			},
 			"content": schema.StringAttribute{
-				Required:    true,
-				Description: "The content of the template",
+				CustomType:  templateContentType{},
+				Optional:    true,
+				Computed:    true,
+				Description: "The content of the template, written in Terrakube Configuration Language. It is validated during plan, changes in formatting, quoting, comments or key order are not a difference. Exactly one of `content` or `flow` must be set, when `flow` is used this is the rendered YAML.",
+				Validators: []validator.String{
+					tclContentValidator{},
+				},
+			},
+			"flow_steps": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The steps of the template flow, in the order they run.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"step": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The step number.",
+						},
+						"type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The flow type, for example `terraformPlan` or `approval`.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the step.",
+						},
+						"team": schema.StringAttribute{
+							Computed:    true,
+							Description: "The team that approves the step, for approval steps.",
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"flow": schema.ListNestedBlock{
+				Description: "A step of the template flow, rendered into `content` by the provider. Use it instead of writing `content` by hand.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Required:    true,
+							Description: "The flow type, for example `terraformPlan`, `terraformApply` or `approval`.",
+							Validators: []validator.String{
+								stringvalidator.OneOf(tcl.FlowTypes...),
+							},
+						},
+						"name": schema.StringAttribute{
+							Optional:    true,
+							Description: "The name of the step.",
+						},
+						"step": schema.Int64Attribute{
+							Required:    true,
+							Description: "The step number, steps run from the lowest to the highest number.",
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+						"team": schema.StringAttribute{
+							Optional:    true,
+							Description: "The team that approves the step, required for `approval` steps.",
+						},
+					},
+					Blocks: map[string]schema.Block{
+						"command": schema.ListNestedBlock{
+							Description: "A script run before or after the step.",
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"runtime": schema.StringAttribute{
+										Required:    true,
+										Description: "The script runtime, `BASH` or `GROOVY`.",
+										Validators: []validator.String{
+											stringvalidator.OneOf(tcl.Runtimes...),
+										},
+									},
+									"priority": schema.Int64Attribute{
+										Required:    true,
+										Description: "The order commands run in, from the lowest to the highest priority.",
+										Validators: []validator.Int64{
+											int64validator.AtLeast(0),
+										},
+									},
+									"script": schema.StringAttribute{
+										Required:    true,
+										Description: "The script to run.",
+									},
+									"before": schema.BoolAttribute{
+										Optional:    true,
+										Description: "Run the command before the step.",
+									},
+									"after": schema.BoolAttribute{
+										Optional:    true,
+										Description: "Run the command after the step.",
+									},
+								},
+							},
+						},
+					},
+				},
 			},
 		},
 	}
@@ -182,14 +287,23 @@ func (r *OrganizationTemplateResource) Create(ctx context.Context, req resource.
 		resp.Diagnostics.AddError("Error decoding the content from Base64.", fmt.Sprintf("Error decode the tcl: %s", err))
 		return
 	}
-	plan.Content = types.StringValue(string(contentDecoded))
+	plan.Content = newTemplateContentValue(string(contentDecoded))
+	flowSteps, diags := templateFlowSteps(plan.Content.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.FlowSteps = flowSteps
 
 	tflog.Info(ctx, "Organization Template Resource Created", map[string]any{"success": true})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *OrganizationTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.Diagnostics.Append(setResourceIdentity(ctx, req.State, resp.Identity)...)
+
 	var state OrganizationTemplateResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -241,8 +355,22 @@ func (r *OrganizationTemplateResource) Read(ctx context.Context, req resource.Re
 		resp.Diagnostics.AddError("Error decoding the content from Base64.", fmt.Sprintf("Error decode the tcl: %s", err))
 		return
 	}
-	state.Content = types.StringValue(string(contentDecoded))
+	if !templateUsesFlow(state.Flow) {
+		state.Flow = types.ListValueMust(types.ObjectType{AttrTypes: templateFlowAttrTypes}, []attr.Value{})
+	} else if !tcl.Equivalent(state.Content.ValueString(), string(contentDecoded)) {
+		state.Flow, diags = templateFlowBlocks(string(contentDecoded))
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	state.Content = newTemplateContentValue(string(contentDecoded))
 	state.ID = types.StringValue(organizationTemplate.ID)
+	state.FlowSteps, diags = templateFlowSteps(state.Content.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -341,9 +469,16 @@ func (r *OrganizationTemplateResource) Update(ctx context.Context, req resource.
 		resp.Diagnostics.AddError("Error decoding the content from Base64.", fmt.Sprintf("Error decode the tcl: %s", err))
 		return
 	}
-	plan.Content = types.StringValue(string(contentDecoded))
+	plan.Content = newTemplateContentValue(string(contentDecoded))
+	flowSteps, diags := templateFlowSteps(plan.Content.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.FlowSteps = flowSteps
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *OrganizationTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -375,13 +510,122 @@ func (r *OrganizationTemplateResource) Delete(ctx context.Context, req resource.
 	}
 }
 
+func (r *OrganizationTemplateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Do nothing if it's destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan OrganizationTemplateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if templateUsesFlow(plan.Flow) {
+		content, known, diags := renderTemplateFlow(ctx, plan.Flow)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		switch {
+		case !known:
+			plan.Content = newTemplateContentUnknown()
+		case req.State.Raw.IsNull():
+			plan.Content = newTemplateContentValue(content)
+		default:
+			var state OrganizationTemplateResourceModel
+			resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			// Reformatting alone never plans a change.
+			if tcl.Equivalent(state.Content.ValueString(), content) {
+				plan.Content = state.Content
+			} else {
+				plan.Content = newTemplateContentValue(content)
+			}
+		}
+	}
+
+	if plan.Content.IsUnknown() {
+		plan.FlowSteps = types.ListUnknown(types.ObjectType{AttrTypes: flowStepAttrTypes})
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+
+	flowSteps, diags := templateFlowSteps(plan.Content.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.FlowSteps = flowSteps
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *OrganizationTemplateResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config OrganizationTemplateResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	usesFlow := templateUsesFlow(config.Flow)
+	if usesFlow && !config.Content.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("content"), "Conflicting Template Content", "Only one of content or flow blocks can be set.")
+		return
+	}
+	if !usesFlow {
+		if config.Content.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("content"), "Missing Template Content", "One of content or flow blocks must be set.")
+		}
+		return
+	}
+
+	content, known, diags := renderTemplateFlow(ctx, config.Flow)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !known {
+		return
+	}
+
+	_, problems := tcl.Parse(content)
+	for _, problem := range problems {
+		if !problem.Warning {
+			resp.Diagnostics.AddAttributeError(path.Root("flow"), "Invalid Template Flow", problem.Message)
+		}
+	}
+}
+
+func (r *OrganizationTemplateResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema("organization_id", "id")
+}
+
 func (r *OrganizationTemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importStateFromIdentity(ctx, req, resp) {
+		return
+	}
+
+	steps := []importStep{organizationImportStep, {
+		attribute:  "id",
+		kind:       "Template",
+		collection: "organization/%s/template",
+		filter:     "template",
+		fields:     []string{"name"},
+		entity:     new(client.OrganizationTemplateEntity),
+	}}
+	api := importAPI{client: r.client, endpoint: r.endpoint, token: r.token}
+	if api.importByName(ctx, req, resp, steps...) {
+		return
+	}
+
 	idParts := strings.Split(req.ID, ",")
 
 	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: 'organization_ID,ID', Got: %q", req.ID),
+			fmt.Sprintf("Expected import identifier with format: 'organization_ID,ID' or '%s', Got: %q", importNamesFormat(steps...), req.ID),
 		)
 		return
 	}
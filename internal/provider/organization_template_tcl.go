@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-terrakube/internal/tcl"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ validator.String = tclContentValidator{}
+
+var flowStepAttrTypes = map[string]attr.Type{
+	"step": types.Int64Type,
+	"type": types.StringType,
+	"name": types.StringType,
+	"team": types.StringType,
+}
+
+var templateCommandAttrTypes = map[string]attr.Type{
+	"runtime":  types.StringType,
+	"priority": types.Int64Type,
+	"script":   types.StringType,
+	"before":   types.BoolType,
+	"after":    types.BoolType,
+}
+
+var templateFlowAttrTypes = map[string]attr.Type{
+	"type":    types.StringType,
+	"name":    types.StringType,
+	"step":    types.Int64Type,
+	"team":    types.StringType,
+	"command": types.ListType{ElemType: types.ObjectType{AttrTypes: templateCommandAttrTypes}},
+}
+
+type templateFlowModel struct {
+	Type    types.String `tfsdk:"type"`
+	Name    types.String `tfsdk:"name"`
+	Step    types.Int64  `tfsdk:"step"`
+	Team    types.String `tfsdk:"team"`
+	Command types.List   `tfsdk:"command"`
+}
+
+type templateCommandModel struct {
+	Runtime  types.String `tfsdk:"runtime"`
+	Priority types.Int64  `tfsdk:"priority"`
+	Script   types.String `tfsdk:"script"`
+	Before   types.Bool   `tfsdk:"before"`
+	After    types.Bool   `tfsdk:"after"`
+}
+
+// tclContentValidator parses template content as Terrakube Configuration
+// Language, so a malformed flow fails during terraform validate instead of
+// when a job runs.
+type tclContentValidator struct{}
+
+func (v tclContentValidator) Description(ctx context.Context) string {
+	return "value must be a valid Terrakube Configuration Language template"
+}
+
+func (v tclContentValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v tclContentValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	_, problems := tcl.Parse(req.ConfigValue.ValueString())
+	for _, problem := range problems {
+		if problem.Warning {
+			resp.Diagnostics.AddAttributeWarning(req.Path, "Template Content Warning", problem.Error())
+		} else {
+			resp.Diagnostics.AddAttributeError(req.Path, "Invalid Template Content", problem.Error())
+		}
+	}
+}
+
+// templateFlowSteps describes the flow of the template content in the order
+// the steps run. It is null when the content can't be parsed, which only
+// happens for templates managed outside of Terraform.
+func templateFlowSteps(content string) (types.List, diag.Diagnostics) {
+	objectType := types.ObjectType{AttrTypes: flowStepAttrTypes}
+
+	template, _ := tcl.Parse(content)
+	if template == nil {
+		return types.ListNull(objectType), nil
+	}
+
+	steps := []attr.Value{}
+	for _, flow := range template.Steps() {
+		step, diags := types.ObjectValue(flowStepAttrTypes, map[string]attr.Value{
+			"step": types.Int64Value(int64(flow.Step)),
+			"type": types.StringValue(flow.Type),
+			"name": stringValueOrNull(flow.Name),
+			"team": stringValueOrNull(flow.Team),
+		})
+		if diags.HasError() {
+			return types.ListNull(objectType), diags
+		}
+		steps = append(steps, step)
+	}
+
+	return types.ListValue(objectType, steps)
+}
+
+func stringValueOrNull(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// templateUsesFlow reports whether the template is written with flow blocks
+// instead of content.
+func templateUsesFlow(flow types.List) bool {
+	return flow.IsUnknown() || len(flow.Elements()) > 0
+}
+
+// renderTemplateFlow renders flow blocks into template content. The returned
+// bool is false when some of the blocks are not known yet.
+func renderTemplateFlow(ctx context.Context, flow types.List) (string, bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if flow.IsUnknown() {
+		return "", false, diags
+	}
+
+	var flows []templateFlowModel
+	diags.Append(flow.ElementsAs(ctx, &flows, false)...)
+	if diags.HasError() {
+		return "", false, diags
+	}
+
+	template := &tcl.Template{}
+	for _, f := range flows {
+		if f.Type.IsUnknown() || f.Name.IsUnknown() || f.Step.IsUnknown() || f.Team.IsUnknown() || f.Command.IsUnknown() {
+			return "", false, diags
+		}
+
+		var commands []templateCommandModel
+		diags.Append(f.Command.ElementsAs(ctx, &commands, false)...)
+		if diags.HasError() {
+			return "", false, diags
+		}
+
+		step := tcl.Flow{
+			Type: f.Type.ValueString(),
+			Name: f.Name.ValueString(),
+			Step: int(f.Step.ValueInt64()),
+			Team: f.Team.ValueString(),
+		}
+		for _, c := range commands {
+			if c.Runtime.IsUnknown() || c.Priority.IsUnknown() || c.Script.IsUnknown() || c.Before.IsUnknown() || c.After.IsUnknown() {
+				return "", false, diags
+			}
+			step.Commands = append(step.Commands, tcl.Command{
+				Runtime:  c.Runtime.ValueString(),
+				Priority: int(c.Priority.ValueInt64()),
+				Before:   c.Before.ValueBool(),
+				After:    c.After.ValueBool(),
+				Script:   c.Script.ValueString(),
+			})
+		}
+		template.Flow = append(template.Flow, step)
+	}
+
+	content, err := tcl.Render(template)
+	if err != nil {
+		diags.AddError("Error rendering template flow", fmt.Sprintf("Error rendering template flow: %s", err))
+		return "", false, diags
+	}
+	return content, true, diags
+}
+
+// templateFlowBlocks converts template content back into flow blocks, so a
+// template changed outside of Terraform shows up as a diff on the blocks. It
+// is an empty list when the content can't be parsed.
+func templateFlowBlocks(content string) (types.List, diag.Diagnostics) {
+	flowType := types.ObjectType{AttrTypes: templateFlowAttrTypes}
+	commandType := types.ObjectType{AttrTypes: templateCommandAttrTypes}
+
+	flows := []attr.Value{}
+	template, _ := tcl.Parse(content)
+	if template == nil {
+		return types.ListValue(flowType, flows)
+	}
+
+	for _, f := range template.Flow {
+		commands := []attr.Value{}
+		for _, c := range f.Commands {
+			command, diags := types.ObjectValue(templateCommandAttrTypes, map[string]attr.Value{
+				"runtime":  types.StringValue(c.Runtime),
+				"priority": types.Int64Value(int64(c.Priority)),
+				"script":   types.StringValue(c.Script),
+				"before":   boolValueOrNull(c.Before),
+				"after":    boolValueOrNull(c.After),
+			})
+			if diags.HasError() {
+				return types.ListNull(flowType), diags
+			}
+			commands = append(commands, command)
+		}
+
+		flow, diags := types.ObjectValue(templateFlowAttrTypes, map[string]attr.Value{
+			"type":    types.StringValue(f.Type),
+			"name":    stringValueOrNull(f.Name),
+			"step":    types.Int64Value(int64(f.Step)),
+			"team":    stringValueOrNull(f.Team),
+			"command": types.ListValueMust(commandType, commands),
+		})
+		if diags.HasError() {
+			return types.ListNull(flowType), diags
+		}
+		flows = append(flows, flow)
+	}
+
+	return types.ListValue(flowType, flows)
+}
+
+func boolValueOrNull(value bool) types.Bool {
+	if !value {
+		return types.BoolNull()
+	}
+	return types.BoolValue(true)
+}
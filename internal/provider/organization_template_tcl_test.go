@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"terraform-provider-terrakube/internal/tcl"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTclContentValidator(t *testing.T) {
+	ctx := context.Background()
+
+	validate := func(content string) *validator.StringResponse {
+		resp := &validator.StringResponse{}
+		tclContentValidator{}.ValidateString(ctx, validator.StringRequest{
+			Path:        path.Root("content"),
+			ConfigValue: types.StringValue(content),
+		}, resp)
+		return resp
+	}
+
+	resp := validate("flow:\n  - type: \"terraformPlan\"\n    step: 100\n")
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 0 {
+		t.Errorf("expected a valid template to pass, got %v", resp.Diagnostics)
+	}
+
+	resp = validate("flow:\n  - type: \"terraformPlan\"\n    step: 100\n  - type: \"approval\"\n    step: 200\n")
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an approval step without team to fail")
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.HasPrefix(detail, "line 4, column 5:") {
+		t.Errorf("expected a line-precise diagnostic, got %q", detail)
+	}
+
+	resp = validate("flow:\n  - type: \"terraformPlan\"\n    step: 100\n    tem: \"typo\"\n")
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+		t.Errorf("expected an unknown key to only warn, got %v", resp.Diagnostics)
+	}
+}
+
+func TestTemplateFlowSteps(t *testing.T) {
+	steps, diags := templateFlowSteps("flow:\n  - type: \"terraformApply\"\n    step: 200\n  - type: \"approval\"\n    name: \"Approve\"\n    step: 150\n    team: \"OPS\"\n")
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	elements := steps.Elements()
+	if len(elements) != 2 {
+		t.Fatalf("expected 2 steps, got %v", elements)
+	}
+	first, ok := elements[0].(types.Object)
+	if !ok {
+		t.Fatalf("expected an object, got %T", elements[0])
+	}
+	if !first.Attributes()["step"].Equal(types.Int64Value(150)) || !first.Attributes()["team"].Equal(types.StringValue("OPS")) {
+		t.Errorf("expected the approval step to run first, got %s", first)
+	}
+	second, _ := elements[1].(types.Object)
+	if !second.Attributes()["name"].IsNull() {
+		t.Errorf("expected an unnamed step to have a null name, got %s", second)
+	}
+
+	steps, diags = templateFlowSteps("not: [valid")
+	if diags.HasError() || !steps.IsNull() {
+		t.Errorf("expected unparsable content to produce null flow steps, got %s (%v)", steps, diags)
+	}
+}
+
+func TestTemplateFlowBlocks_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	content := "flow:\n  - type: \"customScripts\"\n    name: \"Scan\"\n    step: 100\n    commands:\n      - runtime: \"BASH\"\n        priority: 100\n        before: true\n        script: \"tfsec .\"\n  - type: \"approval\"\n    step: 200\n    team: \"OPS\"\n"
+	flow, diags := templateFlowBlocks(content)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(flow.Elements()) != 2 {
+		t.Fatalf("expected 2 flow blocks, got %s", flow)
+	}
+
+	rendered, known, diags := renderTemplateFlow(ctx, flow)
+	if diags.HasError() || !known {
+		t.Fatalf("expected the flow blocks to render, got known %t (%v)", known, diags)
+	}
+	if !tcl.Equivalent(content, rendered) {
+		t.Errorf("expected the rendered flow to match the content, got:\n%s", rendered)
+	}
+
+	flow, diags = templateFlowBlocks("not: [valid")
+	if diags.HasError() || flow.IsNull() || len(flow.Elements()) != 0 {
+		t.Errorf("expected unparsable content to produce no flow blocks, got %s (%v)", flow, diags)
+	}
+}
+
+func TestRenderTemplateFlow_Unknown(t *testing.T) {
+	ctx := context.Background()
+
+	flow, _ := templateFlowBlocks("flow:\n  - type: \"approval\"\n    step: 100\n    team: \"OPS\"\n")
+	first := flow.Elements()[0].(types.Object).Attributes()
+	first["team"] = types.StringUnknown()
+	flow = types.ListValueMust(flow.ElementType(ctx), []attr.Value{types.ObjectValueMust(templateFlowAttrTypes, first)})
+
+	_, known, diags := renderTemplateFlow(ctx, flow)
+	if diags.HasError() || known {
+		t.Errorf("expected a flow with unknown values not to render, got known %t (%v)", known, diags)
+	}
+}
@@ -3,16 +3,11 @@ package provider
 import (
 	"context"
 	"crypto/tls"
-	"encoding/json"
 	"fmt"
-	"io"
 	"math/big"
 	"net/http"
 	"reflect"
-	"strings"
-	"terraform-provider-terrakube/internal/client"
 
-	"github.com/google/jsonapi"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -113,127 +108,15 @@ func (d *OutputDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	tflog.Info(ctx, state.Workspace.ValueString())
 	tflog.Info(ctx, state.Organization.ValueString())
 
-	orgs := d.ReadDataFromApi(fmt.Sprintf("%s/api/v1/organization?filter[organization]=name==%s", d.endpoint, state.Organization.ValueString()), ctx, resp, new(client.OrganizationEntity))
-
-	if len(orgs) == 0 {
-		resp.Diagnostics.AddError(fmt.Sprintf("Organization %s not found!", state.Organization.String()), state.Organization.String())
-		return
-	}
-
-	var OrganizationID string
-	for _, organization := range orgs {
-		data, _ := organization.(*client.OrganizationEntity)
-		OrganizationID = data.ID
-	}
-
-	//now try to find the Workspace
-	workspaces := d.ReadDataFromApi(fmt.Sprintf("%s/api/v1/organization/%s/workspace?filter[workspace]=name==%s", d.endpoint, OrganizationID, state.Workspace.ValueString()), ctx, resp, new(client.WorkspaceEntity))
-
-	if len(workspaces) == 0 {
-		resp.Diagnostics.AddError(fmt.Sprintf("Workspace %s not found!", state.Workspace.String()), state.Workspace.String())
-		return
-	}
-
-	var WorkspaceId string
-	for _, ws := range workspaces {
-		data, _ := ws.(*client.WorkspaceEntity)
-		WorkspaceId = data.ID
-	}
-	tflog.Info(ctx, WorkspaceId)
-
-	//Now that we found the worspace id we can query for the history
-	Histories := d.ReadDataFromApi(fmt.Sprintf("%s/api/v1/organization/%s/workspace/%s/history?sort=-createdDate", d.endpoint, OrganizationID, WorkspaceId), ctx, resp, new(client.HistoryEntity))
-
-	if len(Histories) == 0 {
-		//No history for this workspace. That is not an error
-		tflog.Info(ctx, "No history information found")
-		return
-	}
-
-	data, _ := Histories[0].(*client.HistoryEntity)
-	tflog.Info(ctx, fmt.Sprintf("%#v", data))
-	//Output contains a link to the Output.json file, which contains the real data we need.
-	OutputUrl := data.Output
-	reqFile, err := http.NewRequest(http.MethodGet, OutputUrl, nil)
-	if err != nil {
-		resp.Diagnostics.AddError("Error creating request", fmt.Sprintf("Error creating request: %s", err))
-		return
-	}
-	reqFile.Header.Add("Authorization", fmt.Sprintf("Bearer %s", d.token))
-	reqFile.Header.Add("Content-Type", "application/vnd.api+json")
-
-	resFile, err := d.client.Do(reqFile)
-	if err != nil {
-		resp.Diagnostics.AddError("Error executing Output datasource request part 4", fmt.Sprintf("Error executing Output datasource request part 4: %s", err))
-		return
-	}
-
-	bodyFile, err := io.ReadAll(resFile.Body)
-	if err != nil {
-		tflog.Error(ctx, fmt.Sprintf("Error reading Output response part 4, response status: %s, error: %s", resFile.Status, err))
-	}
-
-	var result map[string]interface{}
-	err = json.Unmarshal(bodyFile, &result)
-	if err != nil {
-		tflog.Error(ctx, "Error converting json result")
-		return
-	}
-
-	values, test := result["values"].(map[string]interface{})
-	if !test {
-		tflog.Error(ctx, "Error converting values from json result")
-		return
-	}
-	outputs, test := values["outputs"].(map[string]interface{})
-	if !test {
-		tflog.Error(ctx, "Error converting values.outputs from json result")
-		return
-	}
-
-	sensitiveTypes := map[string]attr.Type{}
-	sensitiveValues := map[string]attr.Value{}
-	nonSensitiveTypes := map[string]attr.Type{}
-	nonSensitiveValues := map[string]attr.Value{}
-
-	//walk trhough json outputs
-	for x := range outputs {
-		myOutput, test := outputs[x].(map[string]interface{})
-		if !test {
-			tflog.Error(ctx, "Error converting values.outputs.xx from json result")
-			return
-		} else {
-			attrType, _ := inferAttrType(myOutput["value"])
-			attrValue, _ := convertToAttrValue(myOutput["value"], attrType)
-
-			sensitiveTypes[x] = attrType
-			sensitiveValues[x] = attrValue
-
-			if myOutput["sensitive"] == false {
-				nonSensitiveTypes[x] = attrType
-				nonSensitiveValues[x] = attrValue
-			}
-		}
-	}
-
-	// Create dynamic attribute value for `sensitive_values`
-	obj, diags := types.ObjectValue(sensitiveTypes, sensitiveValues)
+	api := outputAPI{client: d.client, endpoint: d.endpoint, token: d.token}
+	values, nonSensitiveValues, diags := api.readOutputs(ctx, state.Organization, state.Workspace)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	sensitiveOutputs := types.DynamicValue(obj)
 
-	// Create dynamic attribute value for `nonsensitive_values`
-	obj, diags = types.ObjectValue(nonSensitiveTypes, nonSensitiveValues)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	nonSensitiveOutputs := types.DynamicValue(obj)
-
-	state.NonSensitiveValues = nonSensitiveOutputs
-	state.Values = sensitiveOutputs
+	state.NonSensitiveValues = nonSensitiveValues
+	state.Values = values
 
 	diags2 := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags2...)
@@ -430,35 +313,3 @@ func inferAttrType(raw interface{}) (attr.Type, error) {
 		return nil, fmt.Errorf("unsupported type %T", raw)
 	}
 }
-
-func (d *OutputDataSource) ReadDataFromApi(url string, ctx context.Context, resp *datasource.ReadResponse, structType any) (data []interface{}) {
-	regApi, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		resp.Diagnostics.AddError("Error creating Output datasource request", fmt.Sprintf("Error creating Output datasource request: %s", err))
-		return
-	}
-	regApi.Header.Add("Authorization", fmt.Sprintf("Bearer %s", d.token))
-	regApi.Header.Add("Content-Type", "application/vnd.api+json")
-
-	resApi, err := d.client.Do(regApi)
-	if err != nil {
-		resp.Diagnostics.AddError("Error executing Output datasource request", fmt.Sprintf("Error executing Output datasource request: %s", err))
-		return
-	}
-
-	body, err := io.ReadAll(resApi.Body)
-	if err != nil {
-		tflog.Error(ctx, fmt.Sprintf("Error reading Output response, response status: %s, error: %s", resApi.Status, err))
-	}
-
-	tflog.Info(ctx, string(body))
-
-	data, err = jsonapi.UnmarshalManyPayload(strings.NewReader(string(body)), reflect.TypeOf(structType))
-
-	if err != nil {
-		resp.Diagnostics.AddError("Unable to unmarshal payload", fmt.Sprintf("Unable to marshal payload, response status: %s, response body: %s, error: %s", resApi.Status, string(body), err))
-		return
-	}
-
-	return data
-}
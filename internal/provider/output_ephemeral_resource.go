@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &OutputEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &OutputEphemeralResource{}
+
+type OutputEphemeralResource struct {
+	client   *http.Client
+	endpoint string
+	token    string
+}
+
+type OutputEphemeralResourceModel struct {
+	Organization       types.String  `tfsdk:"organization"`
+	Workspace          types.String  `tfsdk:"workspace"`
+	Values             types.Dynamic `tfsdk:"values"`
+	NonSensitiveValues types.Dynamic `tfsdk:"nonsensitive_values"`
+}
+
+func NewOutputEphemeralResource() ephemeral.EphemeralResource {
+	return &OutputEphemeralResource{}
+}
+
+func (r *OutputEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_output"
+}
+
+func (r *OutputEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Read the outputs of a workspace without storing them in the plan or state, " +
+			"so they can be passed to write-only arguments or provider configuration blocks.",
+		Attributes: map[string]schema.Attribute{
+			"workspace": schema.StringAttribute{
+				Required:    true,
+				Description: "Workspace Name",
+			},
+			"organization": schema.StringAttribute{
+				Required:    true,
+				Description: "Organization Name",
+			},
+			"values": schema.DynamicAttribute{
+				Description: `Values of the workspace outputs.`,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"nonsensitive_values": schema.DynamicAttribute{
+				Description: `Non-sensitive values of the workspace outputs.`,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *OutputEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*TerrakubeConnectionData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Output Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *TerrakubeConnectionData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	if providerData.InsecureHttpClient {
+		if custom, ok := http.DefaultTransport.(*http.Transport); ok {
+			customTransport := custom.Clone()
+			customTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+			r.client = &http.Client{Transport: customTransport}
+		} else {
+			r.client = &http.Client{}
+		}
+	} else {
+		r.client = &http.Client{}
+	}
+
+	r.endpoint = providerData.Endpoint
+	r.token = providerData.Token
+
+	tflog.Debug(ctx, "Configuring Output ephemeral resource finished successfully.", map[string]any{"success": true})
+}
+
+func (r *OutputEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data OutputEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	api := outputAPI{client: r.client, endpoint: r.endpoint, token: r.token}
+	values, nonSensitiveValues, diags := api.readOutputs(ctx, data.Organization, data.Workspace)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Values = values
+	data.NonSensitiveValues = nonSensitiveValues
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+
+	tflog.Info(ctx, "Output ephemeral resource opened", map[string]any{"organization": data.Organization.ValueString(), "workspace": data.Workspace.ValueString()})
+}
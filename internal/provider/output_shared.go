@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"terraform-provider-terrakube/internal/client"
+
+	"github.com/google/jsonapi"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// outputAPI bundles the HTTP client/endpoint/token used to look up workspace
+// outputs, shared by the terrakube_output data source and ephemeral resource.
+type outputAPI struct {
+	client   *http.Client
+	endpoint string
+	token    string
+}
+
+// readOutputs finds the latest state history of the workspace and returns all
+// of its outputs together with the subset not marked as sensitive. Both values
+// are null when the workspace has no history yet.
+func (a outputAPI) readOutputs(ctx context.Context, organization, workspace types.String) (types.Dynamic, types.Dynamic, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	orgs, readDiags := a.readDataFromApi(ctx, fmt.Sprintf("%s/api/v1/organization?filter[organization]=name==%s", a.endpoint, organization.ValueString()), new(client.OrganizationEntity))
+	diags.Append(readDiags...)
+
+	if len(orgs) == 0 {
+		diags.AddError(fmt.Sprintf("Organization %s not found!", organization.String()), organization.String())
+		return types.DynamicNull(), types.DynamicNull(), diags
+	}
+
+	var OrganizationID string
+	for _, organization := range orgs {
+		data, _ := organization.(*client.OrganizationEntity)
+		OrganizationID = data.ID
+	}
+
+	//now try to find the Workspace
+	workspaces, readDiags := a.readDataFromApi(ctx, fmt.Sprintf("%s/api/v1/organization/%s/workspace?filter[workspace]=name==%s", a.endpoint, OrganizationID, workspace.ValueString()), new(client.WorkspaceEntity))
+	diags.Append(readDiags...)
+
+	if len(workspaces) == 0 {
+		diags.AddError(fmt.Sprintf("Workspace %s not found!", workspace.String()), workspace.String())
+		return types.DynamicNull(), types.DynamicNull(), diags
+	}
+
+	var WorkspaceId string
+	for _, ws := range workspaces {
+		data, _ := ws.(*client.WorkspaceEntity)
+		WorkspaceId = data.ID
+	}
+	tflog.Info(ctx, WorkspaceId)
+
+	//Now that we found the worspace id we can query for the history
+	Histories, readDiags := a.readDataFromApi(ctx, fmt.Sprintf("%s/api/v1/organization/%s/workspace/%s/history?sort=-createdDate", a.endpoint, OrganizationID, WorkspaceId), new(client.HistoryEntity))
+	diags.Append(readDiags...)
+
+	if len(Histories) == 0 {
+		//No history for this workspace. That is not an error
+		tflog.Info(ctx, "No history information found")
+		return types.DynamicNull(), types.DynamicNull(), diags
+	}
+
+	data, _ := Histories[0].(*client.HistoryEntity)
+	//Output contains a link to the Output.json file, which contains the real data we need.
+	OutputUrl := data.Output
+	reqFile, err := http.NewRequestWithContext(ctx, http.MethodGet, OutputUrl, nil)
+	if err != nil {
+		diags.AddError("Error creating request", fmt.Sprintf("Error creating request: %s", err))
+		return types.DynamicNull(), types.DynamicNull(), diags
+	}
+	reqFile.Header.Add("Authorization", fmt.Sprintf("Bearer %s", a.token))
+	reqFile.Header.Add("Content-Type", "application/vnd.api+json")
+
+	resFile, err := a.client.Do(reqFile)
+	if err != nil {
+		diags.AddError("Error executing Output datasource request part 4", fmt.Sprintf("Error executing Output datasource request part 4: %s", err))
+		return types.DynamicNull(), types.DynamicNull(), diags
+	}
+	defer resFile.Body.Close()
+
+	bodyFile, err := io.ReadAll(resFile.Body)
+	if err != nil {
+		tflog.Error(ctx, fmt.Sprintf("Error reading Output response part 4, response status: %s, error: %s", resFile.Status, err))
+	}
+
+	var result map[string]interface{}
+	err = json.Unmarshal(bodyFile, &result)
+	if err != nil {
+		tflog.Error(ctx, "Error converting json result")
+		return types.DynamicNull(), types.DynamicNull(), diags
+	}
+
+	values, test := result["values"].(map[string]interface{})
+	if !test {
+		tflog.Error(ctx, "Error converting values from json result")
+		return types.DynamicNull(), types.DynamicNull(), diags
+	}
+	outputs, test := values["outputs"].(map[string]interface{})
+	if !test {
+		tflog.Error(ctx, "Error converting values.outputs from json result")
+		return types.DynamicNull(), types.DynamicNull(), diags
+	}
+
+	sensitiveTypes := map[string]attr.Type{}
+	sensitiveValues := map[string]attr.Value{}
+	nonSensitiveTypes := map[string]attr.Type{}
+	nonSensitiveValues := map[string]attr.Value{}
+
+	//walk trhough json outputs
+	for x := range outputs {
+		myOutput, test := outputs[x].(map[string]interface{})
+		if !test {
+			tflog.Error(ctx, "Error converting values.outputs.xx from json result")
+			return types.DynamicNull(), types.DynamicNull(), diags
+		}
+
+		attrType, _ := inferAttrType(myOutput["value"])
+		attrValue, _ := convertToAttrValue(myOutput["value"], attrType)
+
+		sensitiveTypes[x] = attrType
+		sensitiveValues[x] = attrValue
+
+		if myOutput["sensitive"] == false {
+			nonSensitiveTypes[x] = attrType
+			nonSensitiveValues[x] = attrValue
+		}
+	}
+
+	// Create dynamic attribute value for `values`
+	obj, objDiags := types.ObjectValue(sensitiveTypes, sensitiveValues)
+	diags.Append(objDiags...)
+	if diags.HasError() {
+		return types.DynamicNull(), types.DynamicNull(), diags
+	}
+	sensitiveOutputs := types.DynamicValue(obj)
+
+	// Create dynamic attribute value for `nonsensitive_values`
+	obj, objDiags = types.ObjectValue(nonSensitiveTypes, nonSensitiveValues)
+	diags.Append(objDiags...)
+	if diags.HasError() {
+		return types.DynamicNull(), types.DynamicNull(), diags
+	}
+
+	return sensitiveOutputs, types.DynamicValue(obj), diags
+}
+
+func (a outputAPI) readDataFromApi(ctx context.Context, url string, structType any) ([]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	regApi, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		diags.AddError("Error creating Output datasource request", fmt.Sprintf("Error creating Output datasource request: %s", err))
+		return nil, diags
+	}
+	regApi.Header.Add("Authorization", fmt.Sprintf("Bearer %s", a.token))
+	regApi.Header.Add("Content-Type", "application/vnd.api+json")
+
+	resApi, err := a.client.Do(regApi)
+	if err != nil {
+		diags.AddError("Error executing Output datasource request", fmt.Sprintf("Error executing Output datasource request: %s", err))
+		return nil, diags
+	}
+	defer resApi.Body.Close()
+
+	body, err := io.ReadAll(resApi.Body)
+	if err != nil {
+		tflog.Error(ctx, fmt.Sprintf("Error reading Output response, response status: %s, error: %s", resApi.Status, err))
+	}
+
+	data, err := jsonapi.UnmarshalManyPayload(strings.NewReader(string(body)), reflect.TypeOf(structType))
+	if err != nil {
+		diags.AddError("Unable to unmarshal payload", fmt.Sprintf("Unable to marshal payload, response status: %s, response body: %s, error: %s", resApi.Status, string(body), err))
+		return nil, diags
+	}
+
+	return data, diags
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newOutputTestServer(t *testing.T, withHistory bool) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	var server *httptest.Server
+	mux.HandleFunc("/api/v1/organization", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("filter[organization]"); got != "name==acme" {
+			t.Errorf("unexpected organization filter %q", got)
+		}
+		_, _ = w.Write([]byte(`{"data":[{"type":"organization","id":"org-1","attributes":{"name":"acme"}}]}`))
+	})
+	mux.HandleFunc("/api/v1/organization/org-1/workspace", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("filter[workspace]"); got != "name==network" {
+			t.Errorf("unexpected workspace filter %q", got)
+		}
+		_, _ = w.Write([]byte(`{"data":[{"type":"workspace","id":"ws-1","attributes":{"name":"network"}}]}`))
+	})
+	mux.HandleFunc("/api/v1/organization/org-1/workspace/ws-1/history", func(w http.ResponseWriter, r *http.Request) {
+		if !withHistory {
+			_, _ = w.Write([]byte(`{"data":[]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"type":"history","id":"h-1","attributes":{"output":"` + server.URL + `/state/output.json"}}]}`))
+	})
+	mux.HandleFunc("/state/output.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"values":{"outputs":{"vpc_id":{"value":"vpc-123","sensitive":false},"db_password":{"value":"hunter2","sensitive":true}}}}`))
+	})
+
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestOutputAPI_ReadOutputs(t *testing.T) {
+	server := newOutputTestServer(t, true)
+	api := outputAPI{client: server.Client(), endpoint: server.URL, token: "test-token"}
+
+	values, nonSensitiveValues, diags := api.readOutputs(context.Background(), types.StringValue("acme"), types.StringValue("network"))
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	all, ok := values.UnderlyingValue().(types.Object)
+	if !ok {
+		t.Fatalf("expected values to be an object, got %T", values.UnderlyingValue())
+	}
+	if len(all.Attributes()) != 2 {
+		t.Errorf("expected both outputs in values, got %v", all.Attributes())
+	}
+
+	plain, ok := nonSensitiveValues.UnderlyingValue().(types.Object)
+	if !ok {
+		t.Fatalf("expected nonsensitive_values to be an object, got %T", nonSensitiveValues.UnderlyingValue())
+	}
+	if _, found := plain.Attributes()["db_password"]; found {
+		t.Error("expected sensitive outputs to be left out of nonsensitive_values")
+	}
+	if got := plain.Attributes()["vpc_id"]; !got.Equal(types.StringValue("vpc-123")) {
+		t.Errorf("expected vpc_id in nonsensitive_values, got %v", got)
+	}
+}
+
+func TestOutputAPI_ReadOutputsWithoutHistory(t *testing.T) {
+	server := newOutputTestServer(t, false)
+	api := outputAPI{client: server.Client(), endpoint: server.URL, token: "test-token"}
+
+	values, nonSensitiveValues, diags := api.readOutputs(context.Background(), types.StringValue("acme"), types.StringValue("network"))
+	if diags.HasError() {
+		t.Fatalf("a workspace without history must not be an error: %v", diags)
+	}
+	if !values.IsNull() || !nonSensitiveValues.IsNull() {
+		t.Errorf("expected null outputs without history, got %s and %s", values, nonSensitiveValues)
+	}
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &ParseImportIDFunction{}
+
+var parsedImportIDAttrTypes = map[string]attr.Type{
+	"organization_id": types.StringType,
+	"parent_ids":      types.ListType{ElemType: types.StringType},
+	"id":              types.StringType,
+}
+
+type ParseImportIDFunction struct{}
+
+type parsedImportIDModel struct {
+	OrganizationID types.String `tfsdk:"organization_id"`
+	ParentIDs      types.List   `tfsdk:"parent_ids"`
+	ID             types.String `tfsdk:"id"`
+}
+
+func NewParseImportIDFunction() function.Function {
+	return &ParseImportIDFunction{}
+}
+
+func (f *ParseImportIDFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_import_id"
+}
+
+func (f *ParseImportIDFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Split an import identifier into its ids",
+		MarkdownDescription: "Splits a comma separated import identifier, like `organization_id,workspace_id,id`, into an object with the `organization_id`, " +
+			"the `parent_ids` in between, for example the workspace or collection id, and the `id` of the resource itself.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "import_id",
+				Description: "Import identifier with at least the organization id and the resource id.",
+			},
+		},
+		Return: function.ObjectReturn{AttributeTypes: parsedImportIDAttrTypes},
+	}
+}
+
+func (f *ParseImportIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var importID string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &importID))
+	if resp.Error != nil {
+		return
+	}
+
+	parts := strings.Split(importID, ",")
+	if len(parts) < 2 || slices.Contains(parts, "") {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Expected import identifier with format: 'organization_ID,ID' or 'organization_ID,parent_ID,ID', Got: %q", importID))
+		return
+	}
+
+	parentIDs, diags := types.ListValueFrom(ctx, types.StringType, parts[1:len(parts)-1])
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+
+	result := parsedImportIDModel{
+		OrganizationID: types.StringValue(parts[0]),
+		ParentIDs:      parentIDs,
+		ID:             types.StringValue(parts[len(parts)-1]),
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
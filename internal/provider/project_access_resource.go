@@ -27,6 +27,7 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ProjectAccessResource{}
+var _ resource.ResourceWithIdentity = &ProjectAccessResource{}
 var _ resource.ResourceWithImportState = &ProjectAccessResource{}
 var _ resource.ResourceWithConfigValidators = &ProjectAccessResource{}
 
@@ -246,9 +247,12 @@ func (r *ProjectAccessResource) Create(ctx context.Context, req resource.CreateR
 	tflog.Info(ctx, "project access Created", map[string]any{"success": true})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *ProjectAccessResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.Diagnostics.Append(setResourceIdentity(ctx, req.State, resp.Identity)...)
+
 	var state ProjectAccessResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -435,6 +439,7 @@ func (r *ProjectAccessResource) Update(ctx context.Context, req resource.UpdateR
 	plan.Name = types.StringValue(projectAccess.Name)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *ProjectAccessResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -462,13 +467,41 @@ func (r *ProjectAccessResource) Delete(ctx context.Context, req resource.DeleteR
 	defer delResp.Body.Close()
 }
 
+func (r *ProjectAccessResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema("organization_id", "project_id", "id")
+}
+
 func (r *ProjectAccessResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importStateFromIdentity(ctx, req, resp) {
+		return
+	}
+
+	steps := []importStep{organizationImportStep, {
+		attribute:  "project_id",
+		kind:       "Project",
+		collection: "organization/%s/project",
+		filter:     "project",
+		fields:     []string{"name"},
+		entity:     new(client.ProjectEntity),
+	}, {
+		attribute:  "id",
+		kind:       "Team",
+		collection: "organization/%s/project/%s/projectAccess",
+		filter:     "project_access",
+		fields:     []string{"name"},
+		entity:     new(client.ProjectAccessEntity),
+	}}
+	api := importAPI{client: r.client, endpoint: r.endpoint, token: r.token}
+	if api.importByName(ctx, req, resp, steps...) {
+		return
+	}
+
 	idParts := strings.Split(req.ID, ",")
 
 	if len(idParts) != 3 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: 'organization_ID,project_ID,ID', Got: %q", req.ID),
+			fmt.Sprintf("Expected import identifier with format: 'organization_ID,project_ID,ID' or '%s', Got: %q", importNamesFormat(steps...), req.ID),
 		)
 		return
 	}
@@ -11,6 +11,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -19,6 +22,9 @@ import (
 
 // Ensure TerrakubeProvider satisfies various provider interfaces.
 var _ provider.Provider = &TerrakubeProvider{}
+var _ provider.ProviderWithEphemeralResources = &TerrakubeProvider{}
+var _ provider.ProviderWithListResources = &TerrakubeProvider{}
+var _ provider.ProviderWithFunctions = &TerrakubeProvider{}
 
 // TerrakubeProvider defines the provider implementation.
 type TerrakubeProvider struct {
@@ -159,6 +165,8 @@ func (p *TerrakubeProvider) Configure(ctx context.Context, req provider.Configur
 
 	resp.DataSourceData = connection
 	resp.ResourceData = connection
+	resp.EphemeralResourceData = connection
+	resp.ListResourceData = connection
 
 	ctx = tflog.SetField(ctx, "terrakube_endpoint", endpoint)
 	ctx = tflog.SetField(ctx, "terrakube_token", token)
@@ -212,5 +220,36 @@ func (p *TerrakubeProvider) DataSources(ctx context.Context) []func() datasource
 		NewFederatedCredentialDataSource,
 		NewProjectDataSource,
 		NewNotificationConfigurationDataSource,
+		NewEffectiveNotificationsDataSource,
+	}
+}
+
+func (p *TerrakubeProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewTeamTokenEphemeralResource,
+		NewOutputEphemeralResource,
+	}
+}
+
+func (p *TerrakubeProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewWorkspaceCliListResource,
+		NewWorkspaceVcsListResource,
+		NewWorkspaceVariableListResource,
+		NewTeamListResource,
+		NewModuleListResource,
+		NewVcsListResource,
+		NewOrganizationTemplateListResource,
+		NewCollectionListResource,
+	}
+}
+
+func (p *TerrakubeProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewWorkspaceImportIDFunction,
+		NewParseImportIDFunction,
+		NewTokenClaimsFunction,
+		NewQuartzNextFunction,
+		NewWebhookFilterMatchesFunction,
 	}
 }
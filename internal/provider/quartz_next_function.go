@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-terrakube/internal/quartz"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &QuartzNextFunction{}
+
+type QuartzNextFunction struct{}
+
+func NewQuartzNextFunction() function.Function {
+	return &QuartzNextFunction{}
+}
+
+func (f *QuartzNextFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "quartz_next"
+}
+
+func (f *QuartzNextFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "List the next fire times of a Quartz cron expression",
+		MarkdownDescription: "Returns the next `count` fire times, in RFC 3339 format, of a Quartz cron expression like the `schedule` of " +
+			"`terrakube_workspace_schedule`. Times are computed after the `after` RFC 3339 timestamp, in its offset. " +
+			"Pass `plantimestamp()` to list the fire times after the current plan.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "cron",
+				Description: "Quartz cron expression.",
+			},
+			function.Int64Parameter{
+				Name:        "count",
+				Description: "Number of fire times to return, between 0 and 100.",
+			},
+			function.StringParameter{
+				Name:        "after",
+				Description: "RFC 3339 timestamp the fire times are computed after, like `plantimestamp()`.",
+			},
+		},
+		Return: function.ListReturn{ElementType: types.StringType},
+	}
+}
+
+func (f *QuartzNextFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var cron string
+	var count int64
+	var after string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &cron, &count, &after))
+	if resp.Error != nil {
+		return
+	}
+
+	expression, err := quartz.Parse(cron)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Error parsing schedule %q: %s", cron, err))
+		return
+	}
+	if count < 0 || count > 100 {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("The count must be between 0 and 100, got: %d", count))
+		return
+	}
+
+	start, err := time.Parse(time.RFC3339, after)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("Error parsing after %q: %s", after, err))
+		return
+	}
+
+	runs := []string{}
+	for _, run := range expression.NextN(start, int(count)) {
+		runs = append(runs, run.Format(time.RFC3339))
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, runs))
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var identityDescriptions = map[string]string{
+	"id":                      "The id of the resource.",
+	"organization_id":         "Terrakube organization id.",
+	"workspace_id":            "Terrakube workspace id.",
+	"collection_id":           "Terrakube collection id.",
+	"project_id":              "Terrakube project id.",
+	"webhook_id":              "Terrakube webhook id.",
+	"federated_credential_id": "Terrakube federated credential id.",
+}
+
+// resourceIdentitySchema describes the identity of a resource with the ids it
+// is imported with. Every identity attribute is also a state attribute with
+// the same name.
+func resourceIdentitySchema(attributes ...string) identityschema.Schema {
+	schema := identityschema.Schema{Attributes: map[string]identityschema.Attribute{}}
+	for _, name := range attributes {
+		schema.Attributes[name] = identityschema.StringAttribute{
+			RequiredForImport: true,
+			Description:       identityDescriptions[name],
+		}
+	}
+	return schema
+}
+
+// setResourceIdentity copies the identity attributes from state. The identity
+// is nil when Terraform doesn't support resource identity.
+func setResourceIdentity(ctx context.Context, state tfsdk.State, identity *tfsdk.ResourceIdentity) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if identity == nil || state.Raw.IsNull() {
+		return diags
+	}
+
+	for name := range identity.Schema.GetAttributes() {
+		var value types.String
+		diags.Append(state.GetAttribute(ctx, path.Root(name), &value)...)
+		diags.Append(identity.SetAttribute(ctx, path.Root(name), value)...)
+	}
+	return diags
+}
+
+// importStateFromIdentity imports the resource from the identity of an import
+// block. It returns false, without touching the response, when the resource is
+// imported with an import identifier instead.
+func importStateFromIdentity(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) bool {
+	if req.ID != "" || req.Identity == nil {
+		return false
+	}
+
+	for name := range req.Identity.Schema.GetAttributes() {
+		var value types.String
+		resp.Diagnostics.Append(req.Identity.GetAttribute(ctx, path.Root(name), &value)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(name), value)...)
+	}
+	return true
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestResourceIdentity_AttributesMatchState(t *testing.T) {
+	ctx := context.Background()
+
+	for _, newResource := range (&TerrakubeProvider{}).Resources(ctx) {
+		r := newResource()
+
+		metadataResp := &resource.MetadataResponse{}
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "terrakube"}, metadataResp)
+
+		withIdentity, ok := r.(resource.ResourceWithIdentity)
+		if !ok {
+			t.Errorf("%s: expected resource identity support", metadataResp.TypeName)
+			continue
+		}
+
+		schemaResp := &resource.SchemaResponse{}
+		r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+		identityResp := &resource.IdentitySchemaResponse{}
+		withIdentity.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, identityResp)
+
+		if _, ok := identityResp.IdentitySchema.Attributes["id"]; !ok {
+			t.Errorf("%s: expected the identity to include the id", metadataResp.TypeName)
+		}
+		for name := range identityResp.IdentitySchema.Attributes {
+			attribute, ok := schemaResp.Schema.Attributes[name]
+			if !ok {
+				t.Errorf("%s: identity attribute %s is not a state attribute", metadataResp.TypeName, name)
+				continue
+			}
+			if !attribute.GetType().Equal(types.StringType) {
+				t.Errorf("%s: identity attribute %s is not a string", metadataResp.TypeName, name)
+			}
+		}
+	}
+}
+
+func TestResourceIdentity_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	r := &TeamResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	identityResp := &resource.IdentitySchemaResponse{}
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, identityResp)
+
+	identity := &tfsdk.ResourceIdentity{
+		Schema: identityResp.IdentitySchema,
+		Raw: tftypes.NewValue(identityResp.IdentitySchema.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"organization_id": tftypes.NewValue(tftypes.String, "org-1"),
+			"id":              tftypes.NewValue(tftypes.String, "team-1"),
+		}),
+	}
+	resp := &resource.ImportStateResponse{State: tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}}
+	r.ImportState(ctx, resource.ImportStateRequest{Identity: identity}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var organizationID types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("organization_id"), &organizationID)...)
+	if organizationID.ValueString() != "org-1" {
+		t.Errorf("expected the organization id to be imported from the identity, got %s", organizationID)
+	}
+
+	newIdentity := &tfsdk.ResourceIdentity{
+		Schema: identityResp.IdentitySchema,
+		Raw:    tftypes.NewValue(identityResp.IdentitySchema.Type().TerraformType(ctx), nil),
+	}
+	diags := setResourceIdentity(ctx, resp.State, newIdentity)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if !newIdentity.Raw.Equal(identity.Raw) {
+		t.Errorf("expected the identity to be set from state, got %s", newIdentity.Raw)
+	}
+}
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// sensitiveValueHashScheme prefixes every stored value hash so the format can
+// change later without misreading hashes written by older provider versions.
+const sensitiveValueHashScheme = "sha256"
+
+// sensitiveValueSnapshot is what a variable-like resource (workspace variable,
+// organization variable, collection item) remembers about the value it last
+// wrote. Plain values are compared through a salted hash. The API never
+// returns sensitive values and write-only values aren't kept in state, so
+// for them the server's updatedDate is the only way Read can tell "the API
+// is hiding the value" apart from "someone rotated the value in the UI". They
+// are never hashed: a hash would be a verifier of the secret stored in state.
+//
+// The updatedDate changes with any attribute of the variable, so an edit of
+// only its description outside of Terraform is also taken for a rotation.
+//
+// WriteOnlyVersion is the value_wo_version trigger. When the value is managed
+// through value_wo there is no value in state to null out, so a detected
+// rotation nulls the trigger instead to get an update planned.
+type sensitiveValueSnapshot struct {
+	Value            types.String
+	Hash             types.String
+	Version          types.String
+	WriteOnlyVersion types.Int64
+}
+
+// hashSensitiveValue returns "sha256:<salt>:<hex digest>" for value. The salt
+// keeps identical secrets from producing identical hashes across resources.
+func hashSensitiveValue(salt, value string) string {
+	digest := sha256.Sum256([]byte(salt + value))
+	return fmt.Sprintf("%s:%s:%s", sensitiveValueHashScheme, salt, hex.EncodeToString(digest[:]))
+}
+
+// newSensitiveValueHash hashes value with a freshly generated salt.
+func newSensitiveValueHash(value string) string {
+	return hashSensitiveValue(rand.Text(), value)
+}
+
+// sensitiveValueHashMatches reports whether hash was produced from value.
+// Malformed or unknown-scheme hashes never match.
+func sensitiveValueHashMatches(hash, value string) bool {
+	parts := strings.SplitN(hash, ":", 3)
+	if len(parts) != 3 || parts[0] != sensitiveValueHashScheme {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hashSensitiveValue(parts[1], value)), []byte(hash)) == 1
+}
+
+// versionMarkerValue converts the server's updatedDate into the value stored
+// in value_version. Servers that don't expose audit fields return an empty
+// string, which is stored as null so it never looks like a rotation.
+func versionMarkerValue(updatedDate string) types.String {
+	if updatedDate == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(updatedDate)
+}
+
+// writtenValueSnapshot records a value the provider just sent to the API. The
+// previous hash is kept when it still matches, so re-applying the same value
+// doesn't churn the salt. Hidden, sensitive or write-only, values get no hash.
+func writtenValueSnapshot(previousHash types.String, value types.String, hidden bool, remoteVersion string) sensitiveValueSnapshot {
+	hash := previousHash
+	switch {
+	case hidden:
+		hash = types.StringNull()
+	case hash.IsNull() || hash.IsUnknown() || !sensitiveValueHashMatches(hash.ValueString(), value.ValueString()):
+		hash = types.StringValue(newSensitiveValueHash(value.ValueString()))
+	}
+
+	return sensitiveValueSnapshot{
+		Value:   value,
+		Hash:    hash,
+		Version: versionMarkerValue(remoteVersion),
+	}
+}
+
+// sensitiveValueDrifted reports whether the remote value changed since the
+// provider last wrote it. Sensitive and write-only values, which have no
+// hash, are compared through the server's version marker; plain values are
+// compared against the stored hash.
+func sensitiveValueDrifted(last sensitiveValueSnapshot, remoteValue string, remoteSensitive bool, remoteVersion string) bool {
+	if remoteSensitive || last.Hash.IsNull() || last.Hash.IsUnknown() {
+		if last.Version.IsNull() || last.Version.IsUnknown() || remoteVersion == "" {
+			return false
+		}
+		return last.Version.ValueString() != remoteVersion
+	}
+	return !sensitiveValueHashMatches(last.Hash.ValueString(), remoteValue)
+}
+
+// refreshSensitiveValue computes the value, hash and version to store after
+// reading a variable-like resource from the API.
+//
+// When a sensitive value was rotated out-of-band the value (or, for
+// write-only values, the value_wo_version trigger) is set to null so the next
+// plan restores the configured value, unless ignoreRemoteChanges is set, in
+// which case the last-written value is kept and the new version is accepted.
+// Write-only values, written values without one in state, are never copied
+// from the response into state. Plain values upgraded from a provider
+// version without value_hash get a hash seeded from their current state
+// value so they don't plan an update, and the hash of a sensitive or
+// write-only value stored by an older version is dropped.
+func refreshSensitiveValue(ctx context.Context, last sensitiveValueSnapshot, ignoreRemoteChanges bool, remoteValue string, remoteSensitive bool, remoteVersion string) sensitiveValueSnapshot {
+	writeOnly := last.Value.IsNull() && (!last.WriteOnlyVersion.IsNull() || !last.Version.IsNull() || !last.Hash.IsNull())
+	switch {
+	case remoteSensitive || writeOnly:
+		last.Hash = types.StringNull()
+	case last.Hash.IsNull() && !last.Value.IsNull() && !last.Value.IsUnknown():
+		last.Hash = types.StringValue(newSensitiveValueHash(last.Value.ValueString()))
+	}
+
+	drifted := sensitiveValueDrifted(last, remoteValue, remoteSensitive, remoteVersion)
+	refreshed := sensitiveValueSnapshot{
+		Value:            last.Value,
+		Hash:             last.Hash,
+		Version:          versionMarkerValue(remoteVersion),
+		WriteOnlyVersion: last.WriteOnlyVersion,
+	}
+
+	switch {
+	case ignoreRemoteChanges:
+		if drifted {
+			tflog.Warn(ctx, "Value was changed outside of Terraform, keeping the last written value because ignore_remote_changes is set")
+		}
+	case writeOnly && drifted:
+		tflog.Warn(ctx, "Write-only value was changed outside of Terraform, planning an update to restore the configured value", map[string]any{"last_version": last.Version.ValueString(), "remote_version": remoteVersion})
+		refreshed.WriteOnlyVersion = types.Int64Null()
+		refreshed.Version = last.Version
+	case remoteSensitive && drifted:
+		tflog.Warn(ctx, "Sensitive value was changed outside of Terraform, planning an update to restore the configured value", map[string]any{"last_version": last.Version.ValueString(), "remote_version": remoteVersion})
+		refreshed.Value = types.StringNull()
+		refreshed.Version = last.Version
+	case remoteSensitive || writeOnly:
+		tflog.Info(ctx, "Value is not included in response or is write-only, keeping the current state value")
+	default:
+		tflog.Info(ctx, "Value is included in response...")
+		refreshed.Value = types.StringValue(remoteValue)
+	}
+
+	return refreshed
+}
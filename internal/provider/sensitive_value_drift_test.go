@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSensitiveValueHash_MatchesOnlyOriginalValue(t *testing.T) {
+	hash := newSensitiveValueHash("s3cr3t")
+
+	if !strings.HasPrefix(hash, sensitiveValueHashScheme+":") {
+		t.Fatalf("expected hash to start with %q, got %q", sensitiveValueHashScheme+":", hash)
+	}
+	if strings.Contains(hash, "s3cr3t") {
+		t.Fatalf("hash must not contain the plain value, got %q", hash)
+	}
+	if !sensitiveValueHashMatches(hash, "s3cr3t") {
+		t.Error("expected hash to match the value it was computed from")
+	}
+	if sensitiveValueHashMatches(hash, "rotated") {
+		t.Error("expected hash not to match a different value")
+	}
+	if newSensitiveValueHash("s3cr3t") == hash {
+		t.Error("expected two hashes of the same value to use different salts")
+	}
+}
+
+func TestSensitiveValueHashMatches_RejectsMalformed(t *testing.T) {
+	for _, hash := range []string{"", "abc", "md5:salt:digest", "sha256:salt"} {
+		if sensitiveValueHashMatches(hash, "value") {
+			t.Errorf("expected malformed hash %q not to match", hash)
+		}
+	}
+}
+
+func TestWrittenValueSnapshot_KeepsMatchingHash(t *testing.T) {
+	previous := types.StringValue(newSensitiveValueHash("same"))
+
+	written := writtenValueSnapshot(previous, types.StringValue("same"), false, "2026-01-01T00:00:00Z")
+	if written.Hash != previous {
+		t.Errorf("expected hash to be kept when the value didn't change, got %s", written.Hash)
+	}
+	if written.Version.ValueString() != "2026-01-01T00:00:00Z" {
+		t.Errorf("expected version to be taken from the response, got %s", written.Version)
+	}
+
+	written = writtenValueSnapshot(previous, types.StringValue("changed"), false, "")
+	if written.Hash == previous || !sensitiveValueHashMatches(written.Hash.ValueString(), "changed") {
+		t.Errorf("expected a new hash for the changed value, got %s", written.Hash)
+	}
+	if !written.Version.IsNull() {
+		t.Errorf("expected an empty updatedDate to be stored as null, got %s", written.Version)
+	}
+
+	written = writtenValueSnapshot(previous, types.StringValue("same"), true, "2026-01-01T00:00:00Z")
+	if !written.Hash.IsNull() {
+		t.Errorf("expected no hash for a sensitive value, got %s", written.Hash)
+	}
+}
+
+func TestRefreshSensitiveValue(t *testing.T) {
+	ctx := context.Background()
+	last := sensitiveValueSnapshot{
+		Value:   types.StringValue("written"),
+		Hash:    types.StringValue(newSensitiveValueHash("written")),
+		Version: types.StringValue("v1"),
+	}
+
+	tests := []struct {
+		name          string
+		ignore        bool
+		remoteValue   string
+		sensitive     bool
+		remoteVersion string
+		wantValue     types.String
+		wantVersion   types.String
+	}{
+		{
+			name:          "sensitive unchanged",
+			sensitive:     true,
+			remoteVersion: "v1",
+			wantValue:     types.StringValue("written"),
+			wantVersion:   types.StringValue("v1"),
+		},
+		{
+			name:          "sensitive rotated out-of-band",
+			sensitive:     true,
+			remoteVersion: "v2",
+			wantValue:     types.StringNull(),
+			wantVersion:   types.StringValue("v1"),
+		},
+		{
+			name:          "sensitive rotated but ignored",
+			ignore:        true,
+			sensitive:     true,
+			remoteVersion: "v2",
+			wantValue:     types.StringValue("written"),
+			wantVersion:   types.StringValue("v2"),
+		},
+		{
+			name:        "sensitive without server version marker",
+			sensitive:   true,
+			wantValue:   types.StringValue("written"),
+			wantVersion: types.StringNull(),
+		},
+		{
+			name:          "plain value changed",
+			remoteValue:   "edited",
+			remoteVersion: "v2",
+			wantValue:     types.StringValue("edited"),
+			wantVersion:   types.StringValue("v2"),
+		},
+		{
+			name:          "plain value changed but ignored",
+			ignore:        true,
+			remoteValue:   "edited",
+			remoteVersion: "v2",
+			wantValue:     types.StringValue("written"),
+			wantVersion:   types.StringValue("v2"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := refreshSensitiveValue(ctx, last, tt.ignore, tt.remoteValue, tt.sensitive, tt.remoteVersion)
+			if !got.Value.Equal(tt.wantValue) {
+				t.Errorf("Value = %s, want %s", got.Value, tt.wantValue)
+			}
+			if !got.Version.Equal(tt.wantVersion) {
+				t.Errorf("Version = %s, want %s", got.Version, tt.wantVersion)
+			}
+			if tt.sensitive && !got.Hash.IsNull() {
+				t.Errorf("expected no hash for a sensitive value, got %s", got.Hash)
+			}
+			if !tt.sensitive && got.Hash != last.Hash {
+				t.Errorf("expected the last written hash to be kept, got %s", got.Hash)
+			}
+		})
+	}
+}
+
+func TestRefreshSensitiveValue_SeedsHashForUpgradedState(t *testing.T) {
+	last := sensitiveValueSnapshot{
+		Value:   types.StringValue("from-old-state"),
+		Hash:    types.StringNull(),
+		Version: types.StringNull(),
+	}
+
+	got := refreshSensitiveValue(context.Background(), last, false, "from-old-state", false, "v1")
+	if got.Hash.IsNull() || !sensitiveValueHashMatches(got.Hash.ValueString(), "from-old-state") {
+		t.Errorf("expected a hash seeded from the state value, got %s", got.Hash)
+	}
+	if got.Value.ValueString() != "from-old-state" {
+		t.Errorf("expected the state value to be kept, got %s", got.Value)
+	}
+
+	got = refreshSensitiveValue(context.Background(), last, false, "", true, "v1")
+	if !got.Hash.IsNull() {
+		t.Errorf("expected no hash seeded for a sensitive value, got %s", got.Hash)
+	}
+}
+
+func TestRefreshSensitiveValue_DropsSensitiveHash(t *testing.T) {
+	last := sensitiveValueSnapshot{
+		Value:   types.StringValue("written"),
+		Hash:    types.StringValue(newSensitiveValueHash("written")),
+		Version: types.StringValue("v1"),
+	}
+
+	got := refreshSensitiveValue(context.Background(), last, false, "", true, "v1")
+	if !got.Hash.IsNull() {
+		t.Errorf("expected the hash of a sensitive value to be dropped, got %s", got.Hash)
+	}
+	if got.Value.ValueString() != "written" {
+		t.Errorf("expected the state value to be kept, got %s", got.Value)
+	}
+}
+
+func TestRefreshSensitiveValue_WriteOnlyRotationNullsVersionTrigger(t *testing.T) {
+	last := sensitiveValueSnapshot{
+		Value:            types.StringNull(),
+		Hash:             types.StringValue(newSensitiveValueHash("written")),
+		Version:          types.StringValue("v1"),
+		WriteOnlyVersion: types.Int64Value(3),
+	}
+
+	got := refreshSensitiveValue(context.Background(), last, false, "edited", false, "v2")
+	if !got.Value.IsNull() {
+		t.Errorf("expected a write-only value never to be copied into state, got %s", got.Value)
+	}
+	if !got.WriteOnlyVersion.IsNull() {
+		t.Errorf("expected value_wo_version to be nulled so an update is planned, got %s", got.WriteOnlyVersion)
+	}
+
+	if !got.Hash.IsNull() {
+		t.Errorf("expected no hash for a write-only value, got %s", got.Hash)
+	}
+
+	got = refreshSensitiveValue(context.Background(), last, false, "written", false, "v1")
+	if !got.Value.IsNull() || got.WriteOnlyVersion.ValueInt64() != 3 {
+		t.Errorf("expected an unchanged write-only value to keep its state, got value %s version %s", got.Value, got.WriteOnlyVersion)
+	}
+
+	// After a rotation the trigger is null, the recorded version still marks
+	// the value as write-only so it isn't copied into state.
+	last = sensitiveValueSnapshot{Value: types.StringNull(), Hash: types.StringNull(), Version: types.StringValue("v1"), WriteOnlyVersion: types.Int64Null()}
+	got = refreshSensitiveValue(context.Background(), last, false, "edited", false, "v2")
+	if !got.Value.IsNull() || !got.WriteOnlyVersion.IsNull() {
+		t.Errorf("expected a rotated write-only value to stay out of state, got value %s version %s", got.Value, got.WriteOnlyVersion)
+	}
+}
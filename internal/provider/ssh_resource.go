@@ -11,6 +11,7 @@ import (
 	"terraform-provider-terrakube/internal/client"
 
 	"github.com/google/jsonapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
@@ -25,6 +26,7 @@ import (
 )
 
 var _ resource.Resource = &SshResource{}
+var _ resource.ResourceWithIdentity = &SshResource{}
 var _ resource.ResourceWithImportState = &SshResource{}
 
 type SshResource struct {
@@ -34,12 +36,14 @@ type SshResource struct {
 }
 
 type SshResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	Name           types.String `tfsdk:"name"`
-	OrganizationId types.String `tfsdk:"organization_id"`
-	Description    types.String `tfsdk:"description"`
-	PrivateKey     types.String `tfsdk:"private_key"`
-	SshType        types.String `tfsdk:"ssh_type"`
+	ID                  types.String `tfsdk:"id"`
+	Name                types.String `tfsdk:"name"`
+	OrganizationId      types.String `tfsdk:"organization_id"`
+	Description         types.String `tfsdk:"description"`
+	PrivateKey          types.String `tfsdk:"private_key"`
+	PrivateKeyWo        types.String `tfsdk:"private_key_wo"`
+	PrivateKeyWoVersion types.Int64  `tfsdk:"private_key_wo_version"`
+	SshType             types.String `tfsdk:"ssh_type"`
 }
 
 func NewSshResource() resource.Resource {
@@ -80,6 +84,25 @@ func (r *SshResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 				Optional:    true,
 				Sensitive:   true,
 				Description: "SSH Key content",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("private_key_wo")),
+				},
+			},
+			"private_key_wo": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Description: "Write-only SSH key content, never stored in the Terraform state. Requires Terraform 1.11 or later and `private_key_wo_version`.",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("private_key_wo_version")),
+				},
+			},
+			"private_key_wo_version": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Version of `private_key_wo`. Change it to send a new `private_key_wo` to Terrakube.",
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("private_key_wo")),
+				},
 			},
 			"ssh_type": schema.StringAttribute{
 				Optional:    true,
@@ -134,10 +157,16 @@ func (r *SshResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
+	privateKey, diags := secretValue(ctx, req.Config, plan.PrivateKey, "private_key_wo")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	bodyRequest := &client.SshEntity{
 		Name:        plan.Name.ValueString(),
 		Description: plan.Description.ValueStringPointer(),
-		PrivateKey:  plan.PrivateKey.ValueString(),
+		PrivateKey:  privateKey.ValueString(),
 		SshType:     plan.SshType.ValueString(),
 	}
 
@@ -176,16 +205,18 @@ func (r *SshResource) Create(ctx context.Context, req resource.CreateRequest, re
 
 	plan.ID = types.StringValue(newSshKey.ID)
 	plan.Name = types.StringValue(newSshKey.Name)
-	plan.PrivateKey = types.StringValue(plan.PrivateKey.ValueString())
 	plan.SshType = types.StringValue(newSshKey.SshType)
 	plan.Description = types.StringPointerValue(newSshKey.Description)
 	tflog.Info(ctx, "Ssh Key Resource Created", map[string]any{"success": true})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *SshResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.Diagnostics.Append(setResourceIdentity(ctx, req.State, resp.Identity)...)
+
 	var state SshResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -229,7 +260,7 @@ func (r *SshResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 
 	tflog.Info(ctx, "Body Response", map[string]any{"bodyResponse": string(bodyResponse)})
 	state.Name = types.StringValue(sshKey.Name)
-	state.PrivateKey = types.StringValue(state.PrivateKey.ValueString()) //value is not inside the response getting the value from the current state
+	//private key is not inside the response, keeping the value from the current state
 	state.SshType = types.StringValue(sshKey.SshType)
 	state.Description = types.StringPointerValue(sshKey.Description)
 	state.ID = types.StringValue(sshKey.ID)
@@ -255,11 +286,17 @@ func (r *SshResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
+	privateKey, diags := secretValue(ctx, req.Config, plan.PrivateKey, "private_key_wo")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	bodyRequest := &client.SshEntity{
 		ID:          plan.ID.ValueString(),
 		Name:        plan.Name.ValueString(),
 		Description: plan.Description.ValueStringPointer(),
-		PrivateKey:  plan.PrivateKey.ValueString(),
+		PrivateKey:  privateKey.ValueString(),
 		SshType:     plan.SshType.ValueString(),
 	}
 	var out = new(bytes.Buffer)
@@ -321,11 +358,11 @@ func (r *SshResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	plan.ID = types.StringValue(state.ID.ValueString())
 	plan.Name = types.StringValue(ssh.Name)
 	plan.Description = types.StringPointerValue(ssh.Description)
-	plan.PrivateKey = types.StringValue(plan.PrivateKey.ValueString())
 	plan.SshType = types.StringValue(ssh.SshType)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *SshResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -352,13 +389,34 @@ func (r *SshResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 	}
 }
 
+func (r *SshResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema("organization_id", "id")
+}
+
 func (r *SshResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importStateFromIdentity(ctx, req, resp) {
+		return
+	}
+
+	steps := []importStep{organizationImportStep, {
+		attribute:  "id",
+		kind:       "SSH key",
+		collection: "organization/%s/ssh",
+		filter:     "ssh",
+		fields:     []string{"name"},
+		entity:     new(client.SshEntity),
+	}}
+	api := importAPI{client: r.client, endpoint: r.endpoint, token: r.token}
+	if api.importByName(ctx, req, resp, steps...) {
+		return
+	}
+
 	idParts := strings.Split(req.ID, ",")
 
 	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: 'organization_ID,ID', Got: %q", req.ID),
+			fmt.Sprintf("Expected import identifier with format: 'organization_ID,ID' or '%s', Got: %q", importNamesFormat(steps...), req.ID),
 		)
 		return
 	}
@@ -0,0 +1,18 @@
+package provider
+
+import (
+	"terraform-provider-terrakube/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+func NewTeamListResource() list.ListResource {
+	return &entityListResource{
+		managed:     NewTeamResource(),
+		description: "List the teams of an organization.",
+		parents:     []string{"organization_id"},
+		collection:  "organization/%s/team",
+		entity:      new(client.TeamEntity),
+		displayName: entityName,
+	}
+}
@@ -0,0 +1,196 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"terraform-provider-terrakube/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// teamTokenPrivateKey is the private data key the token id is stored under
+// between Open and Close.
+const teamTokenPrivateKey = "team_token_id"
+
+// defaultEphemeralTeamTokenDescription is used when description is omitted.
+const defaultEphemeralTeamTokenDescription = "Ephemeral token created by Terraform"
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &TeamTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &TeamTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &TeamTokenEphemeralResource{}
+
+type TeamTokenEphemeralResource struct {
+	client   *http.Client
+	endpoint string
+	token    string
+}
+
+type TeamTokenEphemeralResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Group       types.String `tfsdk:"team_name"`
+	Description types.String `tfsdk:"description"`
+	Days        types.Int32  `tfsdk:"days"`
+	Hours       types.Int32  `tfsdk:"hours"`
+	Minutes     types.Int32  `tfsdk:"minutes"`
+	Value       types.String `tfsdk:"value"`
+}
+
+func NewTeamTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &TeamTokenEphemeralResource{}
+}
+
+func (r *TeamTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_team_token"
+}
+
+func (r *TeamTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Mint a short-lived team token that is revoked as soon as Terraform is done with it. The token is never stored in the plan or state, " +
+			"so it can be passed to write-only arguments or provider configuration blocks.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Team Token Id",
+			},
+			"team_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the team who owns the token.",
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("A description of this token. Default `%s`.", defaultEphemeralTeamTokenDescription),
+			},
+			"days": schema.Int32Attribute{
+				Optional:    true,
+				Description: "The number of days this token is valid for.",
+				Validators: []validator.Int32{
+					int32validator.AtLeast(0),
+				},
+			},
+			"hours": schema.Int32Attribute{
+				Optional:    true,
+				Description: "The number of hours this token is valid for. Defaults to 1 hour when `days`, `hours` and `minutes` are all unset.",
+				Validators: []validator.Int32{
+					int32validator.AtLeast(0),
+				},
+			},
+			"minutes": schema.Int32Attribute{
+				Optional:    true,
+				Description: "The number of minutes this token is valid for.",
+				Validators: []validator.Int32{
+					int32validator.AtLeast(0),
+				},
+			},
+			"value": schema.StringAttribute{
+				Computed:    true,
+				Description: "The value of the token.",
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func (r *TeamTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*TerrakubeConnectionData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Team Token Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *TerrakubeConnectionData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	if providerData.InsecureHttpClient {
+		if custom, ok := http.DefaultTransport.(*http.Transport); ok {
+			customTransport := custom.Clone()
+			customTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+			r.client = &http.Client{Transport: customTransport}
+		} else {
+			r.client = &http.Client{}
+		}
+	} else {
+		r.client = &http.Client{}
+	}
+
+	r.endpoint = providerData.Endpoint
+	r.token = providerData.Token
+
+	tflog.Debug(ctx, "Configuring Team Token ephemeral resource finished successfully.", map[string]any{"success": true})
+}
+
+func (r *TeamTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data TeamTokenEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Description.IsNull() {
+		data.Description = types.StringValue(defaultEphemeralTeamTokenDescription)
+	}
+	if data.Days.IsNull() && data.Hours.IsNull() && data.Minutes.IsNull() {
+		data.Hours = types.Int32Value(1)
+	}
+
+	bodyRequest := &client.TeamTokenEntity{
+		Description: data.Description.ValueString(),
+		Days:        data.Days.ValueInt32(),
+		Hours:       data.Hours.ValueInt32(),
+		Minutes:     data.Minutes.ValueInt32(),
+		Group:       data.Group.ValueString(),
+	}
+
+	api := teamTokenAPI{client: r.client, endpoint: r.endpoint, token: r.token}
+	id, value, diags := api.createTeamToken(ctx, bodyRequest)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(id)
+	data.Value = types.StringValue(value)
+
+	privateID, err := json.Marshal(id)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to marshal team token id", fmt.Sprintf("Unable to marshal team token id: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, teamTokenPrivateKey, privateID)...)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+
+	tflog.Info(ctx, "Team Token ephemeral resource opened", map[string]any{"id": id})
+}
+
+func (r *TeamTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	raw, diags := req.Private.GetKey(ctx, teamTokenPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || raw == nil {
+		return
+	}
+
+	var id string
+	if err := json.Unmarshal(raw, &id); err != nil {
+		resp.Diagnostics.AddError("Error reading team token id", fmt.Sprintf("Error reading team token id from private data: %s", err))
+		return
+	}
+
+	api := teamTokenAPI{client: r.client, endpoint: r.endpoint, token: r.token}
+	resp.Diagnostics.Append(api.deleteTeamToken(ctx, id)...)
+
+	tflog.Info(ctx, "Team Token ephemeral resource closed", map[string]any{"id": id})
+}
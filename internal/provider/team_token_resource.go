@@ -9,7 +9,7 @@ import (
 	"net/http"
 	"strings"
 	"terraform-provider-terrakube/internal/client"
-	"terraform-provider-terrakube/internal/helpers"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -18,13 +18,16 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &TeamTokenResource{}
+var _ resource.ResourceWithIdentity = &TeamTokenResource{}
 var _ resource.ResourceWithImportState = &TeamTokenResource{}
+var _ resource.ResourceWithModifyPlan = &TeamTokenResource{}
 
 type TeamTokenResource struct {
 	client   *http.Client
@@ -33,13 +36,15 @@ type TeamTokenResource struct {
 }
 
 type TeamTokenResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Group       types.String `tfsdk:"team_name"`
-	Description types.String `tfsdk:"description"`
-	Days        types.Int32  `tfsdk:"days"`
-	Hours       types.Int32  `tfsdk:"hours"`
-	Minutes     types.Int32  `tfsdk:"minutes"`
-	Value       types.String `tfsdk:"value"`
+	ID           types.String `tfsdk:"id"`
+	Group        types.String `tfsdk:"team_name"`
+	Description  types.String `tfsdk:"description"`
+	Days         types.Int32  `tfsdk:"days"`
+	Hours        types.Int32  `tfsdk:"hours"`
+	Minutes      types.Int32  `tfsdk:"minutes"`
+	Value        types.String `tfsdk:"value"`
+	ExpiresAt    types.String `tfsdk:"expires_at"`
+	RotateBefore types.String `tfsdk:"rotate_before"`
 }
 
 func NewTeamTokenResource() resource.Resource {
@@ -101,6 +106,25 @@ func (r *TeamTokenResource) Schema(ctx context.Context, req resource.SchemaReque
 				Computed:    true,
 				Description: "The value of the token.",
 				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The time the token expires, in RFC 3339 format. Null when the token doesn't expire.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rotate_before": schema.StringAttribute{
+				Optional: true,
+				Description: "How long before `expires_at` the token should be rotated, as a duration such as `72h` or `30m`. " +
+					"When the remaining lifetime is shorter than this during plan, the token is replaced. " +
+					"Combine it with `lifecycle { create_before_destroy = true }` so the new token exists before the old one is revoked.",
+				Validators: []validator.String{
+					positiveDurationValidator{},
+				},
 			},
 		},
 	}
@@ -156,54 +180,29 @@ func (r *TeamTokenResource) Create(ctx context.Context, req resource.CreateReque
 		Group:       plan.Group.ValueString(),
 	}
 
-	bodyJson, err := json.Marshal(bodyRequest)
-	if err != nil {
-		resp.Diagnostics.AddError("Unable to marshal request ", fmt.Sprintf("Unable to marshal request, error: %s", err))
-		return
-	}
-
-	teamTokenRequest, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/access-token/v1/teams", r.endpoint), strings.NewReader(string(bodyJson)))
-	if err != nil {
-		resp.Diagnostics.AddError("Error creating team token resource request", fmt.Sprintf("Error creating team token resource request: %s", err))
-		return
-	}
-	teamTokenRequest.Header.Add("Authorization", fmt.Sprintf("Bearer %s", r.token))
-	teamTokenRequest.Header.Add("Content-Type", "application/vnd.api+json")
-
-	teamTokenResponse, err := r.client.Do(teamTokenRequest)
-	if err != nil {
-		resp.Diagnostics.AddError("Error executing team token resource request", fmt.Sprintf("Error executing team token resource request: %s", err))
+	api := teamTokenAPI{client: r.client, endpoint: r.endpoint, token: r.token}
+	id, value, diags := api.createTeamToken(ctx, bodyRequest)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-
-	bodyResponse, err := io.ReadAll(teamTokenResponse.Body)
-	if err != nil {
-		tflog.Error(ctx, "Error reading team token resource response")
-	}
-	newTeamToken := &client.TeamTokenEntity{}
-
-	err = json.Unmarshal(bodyResponse, newTeamToken)
-
-	if err != nil {
-		resp.Diagnostics.AddError("Error unmarshal payload response", fmt.Sprintf("Error unmarshal payload response, error: %s, response status: %s", err, teamTokenResponse.Status))
+	plan.ID = types.StringValue(id)
+	plan.Value = types.StringValue(value)
+	plan.ExpiresAt, diags = teamTokenExpiresAt(value)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	tflog.Info(ctx, "Body Response Status", map[string]any{"responseStatus": teamTokenResponse.Status})
-
-	id, err := helpers.GetIDFromToken(newTeamToken.Value)
-	if err != nil {
-		resp.Diagnostics.AddError("Error getting claim from token", fmt.Sprintf("Error getting claim from token: %s", err))
-	}
-	plan.ID = types.StringValue(id)
-	plan.Value = types.StringValue(newTeamToken.Value)
-
 	tflog.Info(ctx, "Team Token Resource Created", map[string]any{"success": true})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *TeamTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.Diagnostics.Append(setResourceIdentity(ctx, req.State, resp.Identity)...)
+
 	var state TeamTokenResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -252,6 +251,10 @@ func (r *TeamTokenResource) Read(ctx context.Context, req resource.ReadRequest,
 		state.Hours = types.Int32Value(teamToken.Hours)
 		state.Minutes = types.Int32Value(teamToken.Minutes)
 		state.Group = types.StringValue(teamToken.Group)
+		if state.ExpiresAt.IsNull() && state.Value.ValueString() != "" {
+			state.ExpiresAt, diags = teamTokenExpiresAt(state.Value.ValueString())
+			resp.Diagnostics.Append(diags...)
+		}
 		found = true
 		break
 	}
@@ -273,7 +276,18 @@ func (r *TeamTokenResource) Read(ctx context.Context, req resource.ReadRequest,
 }
 
 func (r *TeamTokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan TeamTokenResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only rotate_before can change in place, every other argument re-creates the token.
 	tflog.Info(ctx, "Team token can't be updated but re-create.", map[string]any{"success": true})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *TeamTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -286,25 +300,83 @@ func (r *TeamTokenResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	reqToken, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/access-token/v1/teams/%s", r.endpoint, data.ID.ValueString()), nil)
-	if err != nil {
-		resp.Diagnostics.AddError("Error deleting team token resource request", fmt.Sprintf("Error deleting team token resource request: %s", err))
+	api := teamTokenAPI{client: r.client, endpoint: r.endpoint, token: r.token}
+	resp.Diagnostics.Append(api.deleteTeamToken(ctx, data.ID.ValueString())...)
+}
+
+func (r *TeamTokenResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Do nothing if it's destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan TeamTokenResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.RotateBefore.IsNull() || plan.RotateBefore.IsUnknown() {
 		return
 	}
-	reqToken.Header.Add("Authorization", fmt.Sprintf("Bearer %s", r.token))
 
-	resToken, err := r.client.Do(reqToken)
+	// positiveDurationValidator already reported a duration that doesn't parse.
+	rotateBefore, err := time.ParseDuration(plan.RotateBefore.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error deleting team token", fmt.Sprintf("Error deleting team token: %s", err))
 		return
 	}
-	if resToken.StatusCode != http.StatusAccepted {
-		deleteBody, _ := io.ReadAll(resToken.Body)
-		resp.Diagnostics.AddError("Error deleting team token", fmt.Sprintf("Error deleting team token, response status: %s, response body: %s", resToken.Status, string(deleteBody)))
+
+	// A token that is about to be created is always fresh.
+	if req.State.Raw.IsNull() {
 		return
 	}
+
+	var state TeamTokenResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rotate, diags := teamTokenNeedsRotation(state.ExpiresAt, rotateBefore, time.Now())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !rotate {
+		return
+	}
+
+	tflog.Info(ctx, "Team token is close to expiring, planning replacement", map[string]any{"id": state.ID.ValueString(), "expiresAt": state.ExpiresAt.ValueString()})
+
+	plan.ID = types.StringUnknown()
+	plan.Value = types.StringUnknown()
+	plan.ExpiresAt = types.StringUnknown()
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("expires_at"))
+}
+
+func (r *TeamTokenResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema("id")
 }
 
 func (r *TeamTokenResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importStateFromIdentity(ctx, req, resp) {
+		return
+	}
+
+	// Tokens are found by the name of their team and their description, which
+	// may contain slashes. Any other identifier is the id of the token.
+	if names, ok := importNames(req.ID); ok && len(names) > 1 {
+		if names[0] == "" || strings.Join(names[1:], "/") == "" {
+			resp.Diagnostics.AddError(
+				"Unexpected Import Identifier",
+				fmt.Sprintf("Expected import identifier with format: 'ID' or 'team_name/token_description', Got: %q", req.ID),
+			)
+			return
+		}
+
+		api := teamTokenAPI{client: r.client, endpoint: r.endpoint, token: r.token}
+		id, err := api.findTeamTokenID(ctx, names[0], strings.Join(names[1:], "/"))
+		if err != nil {
+			resp.Diagnostics.AddError("Error importing team token", fmt.Sprintf("Team token %s: %s", req.ID, err))
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+		return
+	}
+
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"terraform-provider-terrakube/internal/client"
+	"terraform-provider-terrakube/internal/helpers"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ validator.String = positiveDurationValidator{}
+
+// teamTokenAPI bundles the HTTP client/endpoint/token used to mint and revoke
+// team tokens, shared by the terrakube_team_token resource and ephemeral
+// resource.
+type teamTokenAPI struct {
+	client   *http.Client
+	endpoint string
+	token    string
+}
+
+// createTeamToken mints a new team token through access-token/v1/teams and
+// returns its id (the token's jti claim) and value.
+func (a teamTokenAPI) createTeamToken(ctx context.Context, bodyRequest *client.TeamTokenEntity) (string, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	bodyJson, err := json.Marshal(bodyRequest)
+	if err != nil {
+		diags.AddError("Unable to marshal request ", fmt.Sprintf("Unable to marshal request, error: %s", err))
+		return "", "", diags
+	}
+
+	teamTokenRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/access-token/v1/teams", a.endpoint), strings.NewReader(string(bodyJson)))
+	if err != nil {
+		diags.AddError("Error creating team token resource request", fmt.Sprintf("Error creating team token resource request: %s", err))
+		return "", "", diags
+	}
+	teamTokenRequest.Header.Add("Authorization", fmt.Sprintf("Bearer %s", a.token))
+	teamTokenRequest.Header.Add("Content-Type", "application/vnd.api+json")
+
+	teamTokenResponse, err := a.client.Do(teamTokenRequest)
+	if err != nil {
+		diags.AddError("Error executing team token resource request", fmt.Sprintf("Error executing team token resource request: %s", err))
+		return "", "", diags
+	}
+	defer teamTokenResponse.Body.Close()
+
+	bodyResponse, err := io.ReadAll(teamTokenResponse.Body)
+	if err != nil {
+		tflog.Error(ctx, "Error reading team token resource response")
+	}
+	if teamTokenResponse.StatusCode < 200 || teamTokenResponse.StatusCode >= 300 {
+		diags.AddError("Error creating team token", fmt.Sprintf("Error creating team token, response status: %s, response body: %s", teamTokenResponse.Status, string(bodyResponse)))
+		return "", "", diags
+	}
+	newTeamToken := &client.TeamTokenEntity{}
+
+	err = json.Unmarshal(bodyResponse, newTeamToken)
+	if err != nil {
+		diags.AddError("Error unmarshal payload response", fmt.Sprintf("Error unmarshal payload response, error: %s, response status: %s", err, teamTokenResponse.Status))
+		return "", "", diags
+	}
+
+	tflog.Info(ctx, "Body Response Status", map[string]any{"responseStatus": teamTokenResponse.Status})
+
+	id, err := helpers.GetIDFromToken(newTeamToken.Value)
+	if err != nil {
+		diags.AddError("Error getting claim from token", fmt.Sprintf("Error getting claim from token: %s", err))
+		// The token was minted but can't be tracked, revoke it rather than
+		// leave a live credential behind.
+		if newTeamToken.ID == "" {
+			diags.AddError("Unable to revoke team token", "The team token was created but its id is unknown, revoke it in Terrakube.")
+			return "", "", diags
+		}
+		diags.Append(a.deleteTeamToken(ctx, newTeamToken.ID)...)
+		return "", "", diags
+	}
+
+	return id, newTeamToken.Value, diags
+}
+
+// deleteTeamToken revokes the team token with the given id.
+func (a teamTokenAPI) deleteTeamToken(ctx context.Context, id string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	reqToken, err := http.NewRequestWithContext(ctx, http.MethodDelete, fmt.Sprintf("%s/access-token/v1/teams/%s", a.endpoint, id), nil)
+	if err != nil {
+		diags.AddError("Error deleting team token resource request", fmt.Sprintf("Error deleting team token resource request: %s", err))
+		return diags
+	}
+	reqToken.Header.Add("Authorization", fmt.Sprintf("Bearer %s", a.token))
+
+	resToken, err := a.client.Do(reqToken)
+	if err != nil {
+		diags.AddError("Error deleting team token", fmt.Sprintf("Error deleting team token: %s", err))
+		return diags
+	}
+	defer resToken.Body.Close()
+
+	if resToken.StatusCode != http.StatusAccepted {
+		deleteBody, _ := io.ReadAll(resToken.Body)
+		diags.AddError("Error deleting team token", fmt.Sprintf("Error deleting team token, response status: %s, response body: %s", resToken.Status, string(deleteBody)))
+	}
+	return diags
+}
+
+// findTeamTokenID returns the id of the only token of the team with the given
+// description.
+func (a teamTokenAPI) findTeamTokenID(ctx context.Context, team, description string) (string, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/access-token/v1/teams", a.endpoint), nil)
+	if err != nil {
+		return "", fmt.Errorf("error creating request: %w", err)
+	}
+	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", a.token))
+
+	response, err := a.client.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("error executing request: %w", err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", fmt.Errorf("error reading response, response status: %s: %w", response.Status, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected response status: %s, response body: %s", response.Status, string(body))
+	}
+
+	var teamTokens []client.TeamTokenEntity
+	if err := json.Unmarshal(body, &teamTokens); err != nil {
+		return "", fmt.Errorf("unable to unmarshal payload, response body: %s: %w", string(body), err)
+	}
+
+	var ids []string
+	for _, teamToken := range teamTokens {
+		if teamToken.Group == team && teamToken.Description == description {
+			ids = append(ids, teamToken.ID)
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("not found")
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("matches %d tokens, import it by id instead", len(ids))
+	}
+}
+
+// teamTokenExpiresAt returns the exp claim of a team token in RFC 3339 format,
+// or null when the token doesn't expire.
+func teamTokenExpiresAt(value string) (types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	expiresAt, err := helpers.GetExpirationFromToken(value)
+	if err != nil {
+		diags.AddError("Error getting expiration from token", fmt.Sprintf("Error getting expiration from token: %s", err))
+		return types.StringNull(), diags
+	}
+	if expiresAt.IsZero() {
+		return types.StringNull(), diags
+	}
+	return types.StringValue(expiresAt.Format(time.RFC3339)), diags
+}
+
+// teamTokenNeedsRotation reports whether a token expiring at expiresAt has
+// less than rotateBefore left at now. Tokens without an expiry never rotate.
+func teamTokenNeedsRotation(expiresAt types.String, rotateBefore time.Duration, now time.Time) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if expiresAt.IsNull() || expiresAt.IsUnknown() {
+		return false, diags
+	}
+
+	expiry, err := time.Parse(time.RFC3339, expiresAt.ValueString())
+	if err != nil {
+		diags.AddError("Error parsing team token expiration", fmt.Sprintf("Error parsing team token expiration %q: %s", expiresAt.ValueString(), err))
+		return false, diags
+	}
+	return !now.Add(rotateBefore).Before(expiry), diags
+}
+
+// positiveDurationValidator accepts Go durations greater than zero, such as 72h.
+type positiveDurationValidator struct{}
+
+func (v positiveDurationValidator) Description(ctx context.Context) string {
+	return "value must be a positive duration such as 72h or 30m"
+}
+
+func (v positiveDurationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v positiveDurationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if duration, err := time.ParseDuration(req.ConfigValue.ValueString()); err != nil || duration <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("%q is not a positive duration such as \"72h\" or \"30m\".", req.ConfigValue.ValueString()),
+		)
+	}
+}
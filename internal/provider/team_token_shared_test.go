@@ -0,0 +1,229 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"terraform-provider-terrakube/internal/client"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTeamTokenAPI_CreateAndDelete(t *testing.T) {
+	ctx := context.Background()
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"jti": "token-1"}).SignedString([]byte("test-key"))
+	if err != nil {
+		t.Fatalf("signing test token: %v", err)
+	}
+
+	var created client.TeamTokenEntity
+	deleted := ""
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/access-token/v1/teams", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("unexpected method %s on teams collection", r.Method)
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("reading request body: %v", err)
+		}
+		if err := json.Unmarshal(body, &created); err != nil {
+			t.Fatalf("unmarshal request body: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"token":"` + signed + `"}`))
+	})
+	mux.HandleFunc("/access-token/v1/teams/token-1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Fatalf("unexpected method %s on team token", r.Method)
+		}
+		deleted = "token-1"
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	api := teamTokenAPI{client: server.Client(), endpoint: server.URL, token: "test-token"}
+
+	id, value, diags := api.createTeamToken(ctx, &client.TeamTokenEntity{Description: "ci", Hours: 1, Group: "DEVELOPERS"})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if id != "token-1" {
+		t.Errorf("expected id from the jti claim, got %q", id)
+	}
+	if value != signed {
+		t.Errorf("expected the minted token value to be returned")
+	}
+	if created.Group != "DEVELOPERS" || created.Hours != 1 {
+		t.Errorf("unexpected create request body: %+v", created)
+	}
+
+	if diags := api.deleteTeamToken(ctx, id); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if deleted != "token-1" {
+		t.Errorf("expected token-1 to be revoked, got %q", deleted)
+	}
+}
+
+func TestTeamTokenAPI_CreateReportsUnexpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":"not a member of DEVELOPERS"}`))
+	}))
+	defer server.Close()
+
+	api := teamTokenAPI{client: server.Client(), endpoint: server.URL, token: "test-token"}
+	_, _, diags := api.createTeamToken(context.Background(), &client.TeamTokenEntity{Group: "DEVELOPERS"})
+	if !diags.HasError() || diags[0].Summary() != "Error creating team token" {
+		t.Fatalf("expected the status to be reported, got %v", diags)
+	}
+}
+
+func TestTeamTokenAPI_CreateRevokesUnreadableToken(t *testing.T) {
+	deleted := ""
+	mux := http.NewServeMux()
+	mux.HandleFunc("/access-token/v1/teams", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"token-1","token":"not-a-jwt"}`))
+	})
+	mux.HandleFunc("/access-token/v1/teams/token-1", func(w http.ResponseWriter, r *http.Request) {
+		deleted = r.Method
+		w.WriteHeader(http.StatusAccepted)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	api := teamTokenAPI{client: server.Client(), endpoint: server.URL, token: "test-token"}
+	id, value, diags := api.createTeamToken(context.Background(), &client.TeamTokenEntity{Group: "DEVELOPERS"})
+	if !diags.HasError() || id != "" || value != "" {
+		t.Fatalf("expected an error and no token, got %q %q %v", id, value, diags)
+	}
+	if deleted != http.MethodDelete {
+		t.Errorf("expected the minted token to be revoked")
+	}
+}
+
+func TestTeamTokenAPI_DeleteReportsUnexpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("forbidden"))
+	}))
+	defer server.Close()
+
+	api := teamTokenAPI{client: server.Client(), endpoint: server.URL, token: "test-token"}
+	if diags := api.deleteTeamToken(context.Background(), "token-1"); !diags.HasError() {
+		t.Fatal("expected an error diagnostic for a non-202 delete response")
+	}
+}
+
+func TestTeamTokenExpiresAt(t *testing.T) {
+	exp := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"jti": "token-1", "exp": exp.Unix()}).SignedString([]byte("test-key"))
+	if err != nil {
+		t.Fatalf("signing test token: %v", err)
+	}
+
+	got, diags := teamTokenExpiresAt(signed)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if got.ValueString() != "2026-03-01T12:00:00Z" {
+		t.Errorf("expected expires_at from the exp claim, got %s", got)
+	}
+
+	neverExpires, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"jti": "token-2"}).SignedString([]byte("test-key"))
+	if err != nil {
+		t.Fatalf("signing test token: %v", err)
+	}
+	got, diags = teamTokenExpiresAt(neverExpires)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if !got.IsNull() {
+		t.Errorf("expected a null expires_at for a token without exp, got %s", got)
+	}
+}
+
+func TestTeamTokenNeedsRotation(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	expiresAt := types.StringValue("2026-03-04T00:00:00Z")
+
+	tests := []struct {
+		name         string
+		expiresAt    types.String
+		rotateBefore time.Duration
+		want         bool
+	}{
+		{name: "plenty of time left", expiresAt: expiresAt, rotateBefore: 48 * time.Hour, want: false},
+		{name: "inside the rotation window", expiresAt: expiresAt, rotateBefore: 96 * time.Hour, want: true},
+		{name: "exactly at the window boundary", expiresAt: expiresAt, rotateBefore: 72 * time.Hour, want: true},
+		{name: "already expired", expiresAt: types.StringValue("2026-02-01T00:00:00Z"), rotateBefore: 0, want: true},
+		{name: "token never expires", expiresAt: types.StringNull(), rotateBefore: 96 * time.Hour, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := teamTokenNeedsRotation(tt.expiresAt, tt.rotateBefore, now)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if got != tt.want {
+				t.Errorf("teamTokenNeedsRotation() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPositiveDurationValidator(t *testing.T) {
+	tests := map[string]bool{"72h": true, "30m": true, "1h30m": true, "0s": false, "0": false, "-1h": false, "3 days": false}
+
+	for value, valid := range tests {
+		resp := &validator.StringResponse{}
+		positiveDurationValidator{}.ValidateString(context.Background(), validator.StringRequest{
+			Path:        path.Root("rotate_before"),
+			ConfigValue: types.StringValue(value),
+		}, resp)
+		if resp.Diagnostics.HasError() == valid {
+			t.Errorf("%q: expected valid=%t, got %v", value, valid, resp.Diagnostics)
+		}
+	}
+}
+
+func TestTeamTokenAPI_FindTeamTokenID(t *testing.T) {
+	ctx := context.Background()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/access-token/v1/teams", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"id":"token-1","description":"ci/cd","group":"DEVELOPERS"},` +
+			`{"id":"token-2","description":"ci/cd","group":"OPERATORS"},` +
+			`{"id":"token-3","description":"dup","group":"OPERATORS"},` +
+			`{"id":"token-4","description":"dup","group":"OPERATORS"}]`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	api := teamTokenAPI{client: server.Client(), endpoint: server.URL, token: "test-token"}
+
+	if id, err := api.findTeamTokenID(ctx, "OPERATORS", "ci/cd"); err != nil || id != "token-2" {
+		t.Errorf("expected token-2, got %q, %v", id, err)
+	}
+	if _, err := api.findTeamTokenID(ctx, "OPERATORS", "missing"); err == nil {
+		t.Error("expected a missing token to fail")
+	}
+	if _, err := api.findTeamTokenID(ctx, "OPERATORS", "dup"); err == nil {
+		t.Error("expected an ambiguous description to fail")
+	}
+}
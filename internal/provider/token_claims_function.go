@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-terrakube/internal/helpers"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &TokenClaimsFunction{}
+
+type TokenClaimsFunction struct{}
+
+func NewTokenClaimsFunction() function.Function {
+	return &TokenClaimsFunction{}
+}
+
+func (f *TokenClaimsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "token_claims"
+}
+
+func (f *TokenClaimsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Read the claims of a Terrakube token",
+		MarkdownDescription: "Returns the claims of a Terrakube token, like a team or personal access token, as a map of strings. String claims are returned " +
+			"as is and other claims, like `exp`, JSON encoded. The signature of the token is not verified.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "token",
+				Description: "The JWT to read the claims of.",
+			},
+		},
+		Return: function.MapReturn{ElementType: types.StringType},
+	}
+}
+
+func (f *TokenClaimsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var token string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &token))
+	if resp.Error != nil {
+		return
+	}
+
+	claims, err := helpers.GetClaimsFromToken(token)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Unable to parse token: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, claims))
+}
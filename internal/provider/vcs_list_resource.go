@@ -0,0 +1,18 @@
+package provider
+
+import (
+	"terraform-provider-terrakube/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+func NewVcsListResource() list.ListResource {
+	return &entityListResource{
+		managed:     NewVcsResource(),
+		description: "List the VCS connections of an organization.",
+		parents:     []string{"organization_id"},
+		collection:  "organization/%s/vcs",
+		entity:      new(client.VcsEntity),
+		displayName: entityName,
+	}
+}
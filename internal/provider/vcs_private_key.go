@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// normalizeVcsPrivateKey converts the PEM private key of a STANDALONE VCS
+// connection to the unencrypted PKCS8 PEM Terrakube expects. GitHub hands out
+// GitHub App keys as PKCS1, so RSA (PKCS1) and EC (SEC 1) keys are converted
+// instead of failing when Terrakube first signs a token with them.
+func normalizeVcsPrivateKey(privateKey string) (string, crypto.Signer, error) {
+	block, rest := pem.Decode([]byte(strings.TrimSpace(privateKey)))
+	if block == nil {
+		return "", nil, errors.New("the private key is not PEM encoded, it must start with a -----BEGIN ... PRIVATE KEY----- line")
+	}
+	if len(strings.TrimSpace(string(rest))) > 0 {
+		return "", nil, errors.New("the private key contains more than one PEM block")
+	}
+	if block.Type == "ENCRYPTED PRIVATE KEY" || strings.Contains(block.Headers["Proc-Type"], "ENCRYPTED") {
+		return "", nil, errors.New("the private key is encrypted, Terrakube needs it without a passphrase")
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "OPENSSH PRIVATE KEY":
+		return "", nil, errors.New("OpenSSH private keys are not supported, use the PEM key GitHub generated for the app")
+	default:
+		return "", nil, fmt.Errorf("unsupported PEM block %q, expected a PRIVATE KEY, RSA PRIVATE KEY or EC PRIVATE KEY", block.Type)
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("parsing %s: %w", strings.ToLower(block.Type), err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return "", nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", nil, fmt.Errorf("converting the private key to PKCS8: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), signer, nil
+}
+
+// vcsPrivateKeyFingerprint returns the SHA256 fingerprint of the public key of
+// signer, in the format GitHub lists the private keys of a GitHub App with.
+func vcsPrivateKeyFingerprint(signer crypto.Signer) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(der)
+	return "SHA256:" + base64.StdEncoding.EncodeToString(sum[:]), nil
+}
+
+// vcsPrivateKeyFingerprintValue returns the fingerprint of the private key of
+// a STANDALONE VCS connection, null for OAUTH connections and keys that don't
+// parse, which ValidateConfig already reports.
+func vcsPrivateKeyFingerprintValue(connectionType, privateKey types.String) types.String {
+	if connectionType.IsUnknown() || privateKey.IsUnknown() {
+		return types.StringUnknown()
+	}
+	if connectionType.ValueString() != "STANDALONE" || privateKey.ValueString() == "" {
+		return types.StringNull()
+	}
+
+	_, signer, err := normalizeVcsPrivateKey(privateKey.ValueString())
+	if err != nil {
+		return types.StringNull()
+	}
+	fingerprint, err := vcsPrivateKeyFingerprint(signer)
+	if err != nil {
+		return types.StringNull()
+	}
+	return types.StringValue(fingerprint)
+}
+
+// vcsPrivateKeyRequestValue returns the private key to send to Terrakube,
+// converted to PKCS8 for STANDALONE connections.
+func vcsPrivateKeyRequestValue(connectionType, privateKey types.String) (types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if connectionType.ValueString() != "STANDALONE" || privateKey.ValueString() == "" {
+		return privateKey, diags
+	}
+
+	normalized, _, err := normalizeVcsPrivateKey(privateKey.ValueString())
+	if err != nil {
+		diags.AddError("Invalid Private Key", fmt.Sprintf("The private key of a STANDALONE VCS connection must be an unencrypted PEM key: %s.", err))
+		return privateKey, diags
+	}
+	return types.StringValue(normalized), diags
+}
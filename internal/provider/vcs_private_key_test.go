@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNormalizeVcsPrivateKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating RSA key: %s", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating EC key: %s", err)
+	}
+	pkcs8, _ := x509.MarshalPKCS8PrivateKey(rsaKey)
+	ecDer, _ := x509.MarshalECPrivateKey(ecKey)
+
+	encode := func(blockType string, der []byte, headers map[string]string) string {
+		return string(pem.EncodeToMemory(&pem.Block{Type: blockType, Headers: headers, Bytes: der}))
+	}
+	pkcs8PEM := encode("PRIVATE KEY", pkcs8, nil)
+	pkcs1PEM := encode("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey), nil)
+
+	tests := []struct {
+		name string
+		key  string
+		want string
+	}{
+		{name: "pkcs8", key: pkcs8PEM},
+		{name: "pkcs1", key: pkcs1PEM},
+		{name: "pkcs1 with surrounding whitespace", key: "\n  " + pkcs1PEM + "\n"},
+		{name: "ec", key: encode("EC PRIVATE KEY", ecDer, nil)},
+		{name: "not pem", key: "hellotest", want: "not PEM encoded"},
+		{name: "encrypted pkcs8", key: encode("ENCRYPTED PRIVATE KEY", pkcs8, nil), want: "encrypted"},
+		{name: "encrypted pkcs1", key: encode("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey), map[string]string{"Proc-Type": "4,ENCRYPTED", "DEK-Info": "AES-128-CBC,00"}), want: "encrypted"},
+		{name: "openssh", key: encode("OPENSSH PRIVATE KEY", []byte("key"), nil), want: "OpenSSH"},
+		{name: "public key", key: encode("PUBLIC KEY", []byte("key"), nil), want: "unsupported PEM block"},
+		{name: "truncated", key: encode("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey)[:64], nil), want: "parsing rsa private key"},
+		{name: "two keys", key: pkcs1PEM + pkcs8PEM, want: "more than one PEM block"},
+	}
+
+	for _, test := range tests {
+		normalized, _, err := normalizeVcsPrivateKey(test.key)
+		if test.want != "" {
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("%s: expected an error containing %q, got %v", test.name, test.want, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err)
+			continue
+		}
+		block, _ := pem.Decode([]byte(normalized))
+		if block == nil || block.Type != "PRIVATE KEY" {
+			t.Errorf("%s: expected a PKCS8 PEM key, got %q", test.name, normalized)
+			continue
+		}
+		if _, err := x509.ParsePKCS8PrivateKey(block.Bytes); err != nil {
+			t.Errorf("%s: normalized key is not PKCS8: %s", test.name, err)
+		}
+	}
+
+	if normalized, _, _ := normalizeVcsPrivateKey(pkcs1PEM); normalized != pkcs8PEM {
+		t.Errorf("expected a PKCS1 key to convert to the same PKCS8 key openssl pkcs8 -topk8 produces")
+	}
+}
+
+// TestVcsPrivateKeyFingerprintValue covers that converting a key doesn't change
+// its fingerprint, so pasting the PKCS8 form of the same key plans no rotation.
+func TestVcsPrivateKeyFingerprintValue(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating RSA key: %s", err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating RSA key: %s", err)
+	}
+	pkcs8, _ := x509.MarshalPKCS8PrivateKey(rsaKey)
+	pkcs1PEM := types.StringValue(string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})))
+	pkcs8PEM := types.StringValue(string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})))
+	otherPEM := types.StringValue(string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(otherKey)})))
+	standalone := types.StringValue("STANDALONE")
+
+	fingerprint := vcsPrivateKeyFingerprintValue(standalone, pkcs1PEM)
+	if !strings.HasPrefix(fingerprint.ValueString(), "SHA256:") {
+		t.Fatalf("unexpected fingerprint %s", fingerprint)
+	}
+	if got := vcsPrivateKeyFingerprintValue(standalone, pkcs8PEM); !got.Equal(fingerprint) {
+		t.Errorf("expected the PKCS8 form of the key to have fingerprint %s, got %s", fingerprint, got)
+	}
+	if got := vcsPrivateKeyFingerprintValue(standalone, otherPEM); got.Equal(fingerprint) {
+		t.Errorf("expected a rotated key to have another fingerprint")
+	}
+	if got := vcsPrivateKeyFingerprintValue(types.StringValue("OAUTH"), pkcs1PEM); !got.IsNull() {
+		t.Errorf("expected no fingerprint for an OAUTH connection, got %s", got)
+	}
+	if got := vcsPrivateKeyFingerprintValue(standalone, types.StringUnknown()); !got.IsUnknown() {
+		t.Errorf("expected an unknown fingerprint for an unknown key, got %s", got)
+	}
+	if got := vcsPrivateKeyFingerprintValue(standalone, types.StringNull()); !got.IsNull() {
+		t.Errorf("expected no fingerprint without a key, got %s", got)
+	}
+}
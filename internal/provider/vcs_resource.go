@@ -13,7 +13,9 @@ import (
 	"terraform-provider-terrakube/internal/helpers"
 
 	"github.com/google/jsonapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -28,7 +30,10 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &VcsResource{}
+var _ resource.ResourceWithIdentity = &VcsResource{}
 var _ resource.ResourceWithImportState = &VcsResource{}
+var _ resource.ResourceWithModifyPlan = &VcsResource{}
+var _ resource.ResourceWithValidateConfig = &VcsResource{}
 
 type VcsResource struct {
 	client   *http.Client
@@ -37,19 +42,24 @@ type VcsResource struct {
 }
 
 type VcsResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	OrganizationId types.String `tfsdk:"organization_id"`
-	Name           types.String `tfsdk:"name"`
-	Description    types.String `tfsdk:"description"`
-	VcsType        types.String `tfsdk:"vcs_type"`
-	ConnectionType types.String `tfsdk:"connection_type"`
-	ClientId       types.String `tfsdk:"client_id"`
-	ClientSecret   types.String `tfsdk:"client_secret"`
-	PrivateKey     types.String `tfsdk:"private_key"`
-	Endpoint       types.String `tfsdk:"endpoint"`
-	ApiUrl         types.String `tfsdk:"api_url"`
-	Status         types.String `tfsdk:"status"`
-	ConnectUrl     types.String `tfsdk:"connect_url"`
+	ID                    types.String `tfsdk:"id"`
+	OrganizationId        types.String `tfsdk:"organization_id"`
+	Name                  types.String `tfsdk:"name"`
+	Description           types.String `tfsdk:"description"`
+	VcsType               types.String `tfsdk:"vcs_type"`
+	ConnectionType        types.String `tfsdk:"connection_type"`
+	ClientId              types.String `tfsdk:"client_id"`
+	ClientSecret          types.String `tfsdk:"client_secret"`
+	PrivateKey            types.String `tfsdk:"private_key"`
+	ClientSecretWo        types.String `tfsdk:"client_secret_wo"`
+	ClientSecretWoVersion types.Int64  `tfsdk:"client_secret_wo_version"`
+	PrivateKeyWo          types.String `tfsdk:"private_key_wo"`
+	PrivateKeyWoVersion   types.Int64  `tfsdk:"private_key_wo_version"`
+	PrivateKeyFingerprint types.String `tfsdk:"private_key_fingerprint"`
+	Endpoint              types.String `tfsdk:"endpoint"`
+	ApiUrl                types.String `tfsdk:"api_url"`
+	Status                types.String `tfsdk:"status"`
+	ConnectUrl            types.String `tfsdk:"connect_url"`
 }
 
 func NewVcsResource() resource.Resource {
@@ -112,23 +122,69 @@ func (r *VcsResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 				Sensitive:   true,
 				Description: "The secret of the VCS connection",
 				Validators: []validator.String{
-					stringvalidator.AtLeastOneOf(path.MatchRelative().AtParent().AtName("client_secret"), path.MatchRelative().AtParent().AtName("private_key")),
+					vcsSecretAtLeastOneOf(),
+					stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("client_secret_wo")),
 				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"client_secret_wo": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Description: "Write-only secret of the VCS connection, never stored in the Terraform state. Requires Terraform 1.11 or later and `client_secret_wo_version`.",
+				Validators: []validator.String{
+					vcsSecretAtLeastOneOf(),
+					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("client_secret_wo_version")),
+				},
+			},
+			"client_secret_wo_version": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Version of `client_secret_wo`. Changing it recreates the VCS connection with the new `client_secret_wo`, like changing `client_secret` does.",
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("client_secret_wo")),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
 			"private_key": schema.StringAttribute{
 				Optional:    true,
 				Sensitive:   true,
-				Description: "The private key in PKCS8 format of the VCS connection. Please use command `openssl pkcs8 -topk8 -inform PEM -inform pem -outform pem -in github_rsa_private_key.pem -out private_key.pem -nocrypt` to convert the private key to PKCS8 format form Github default RSA.",
+				Description: "The PEM private key of the GitHub App of a `STANDALONE` VCS connection. PKCS1 keys, like the ones GitHub generates, and EC keys are converted to the PKCS8 format Terrakube expects. Encrypted keys are not supported.",
 				Validators: []validator.String{
-					stringvalidator.AtLeastOneOf(path.MatchRelative().AtParent().AtName("client_secret"), path.MatchRelative().AtParent().AtName("private_key")),
+					vcsSecretAtLeastOneOf(),
+					stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("private_key_wo")),
 				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"private_key_wo": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Description: "Write-only PEM private key of the VCS connection, never stored in the Terraform state. Converted to PKCS8 like `private_key`. Requires Terraform 1.11 or later and `private_key_wo_version`.",
+				Validators: []validator.String{
+					vcsSecretAtLeastOneOf(),
+					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("private_key_wo_version")),
+				},
+			},
+			"private_key_wo_version": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Version of `private_key_wo`. Changing it recreates the VCS connection with the new `private_key_wo`, like changing `private_key` does.",
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("private_key_wo")),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"private_key_fingerprint": schema.StringAttribute{
+				Computed:    true,
+				Description: "SHA256 fingerprint of the public key of `private_key` or `private_key_wo`, as GitHub lists it for the GitHub App, so key rotations show up in plans without showing the key.",
+			},
 			"endpoint": schema.StringAttribute{
 				Optional: true,
 				Computed: true,
@@ -204,14 +260,27 @@ func (r *VcsResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
+	clientSecret, diags := secretValue(ctx, req.Config, plan.ClientSecret, "client_secret_wo")
+	resp.Diagnostics.Append(diags...)
+	privateKey, diags := secretValue(ctx, req.Config, plan.PrivateKey, "private_key_wo")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	privateKey, diags = vcsPrivateKeyRequestValue(plan.ConnectionType, privateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	bodyRequest := &client.VcsEntity{
 		Name:           plan.Name.ValueString(),
 		Description:    plan.Description.ValueString(),
 		VcsType:        plan.VcsType.ValueString(),
 		ConnectionType: plan.ConnectionType.ValueString(),
 		ClientId:       plan.ClientId.ValueString(),
-		ClientSecret:   plan.ClientSecret.ValueString(),
-		PrivateKey:     plan.PrivateKey.ValueString(),
+		ClientSecret:   clientSecret.ValueString(),
+		PrivateKey:     privateKey.ValueString(),
 		Endpoint:       plan.Endpoint.ValueString(),
 		ApiUrl:         plan.ApiUrl.ValueString(),
 		Status:         plan.Status.ValueString(),
@@ -277,9 +346,12 @@ func (r *VcsResource) Create(ctx context.Context, req resource.CreateRequest, re
 	tflog.Info(ctx, "VCS Resource Created", map[string]any{"success": true})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *VcsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.Diagnostics.Append(setResourceIdentity(ctx, req.State, resp.Identity)...)
+
 	var state VcsResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -332,6 +404,9 @@ func (r *VcsResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 	state.Endpoint = types.StringValue(vcs.Endpoint)
 	state.ApiUrl = types.StringValue(vcs.ApiUrl)
 	state.Status = types.StringValue(vcs.Status)
+	if state.PrivateKeyFingerprint.IsNull() {
+		state.PrivateKeyFingerprint = vcsPrivateKeyFingerprintValue(state.ConnectionType, state.PrivateKey)
+	}
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -357,6 +432,19 @@ func (r *VcsResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
+	clientSecret, diags := secretValue(ctx, req.Config, plan.ClientSecret, "client_secret_wo")
+	resp.Diagnostics.Append(diags...)
+	privateKey, diags := secretValue(ctx, req.Config, plan.PrivateKey, "private_key_wo")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	privateKey, diags = vcsPrivateKeyRequestValue(plan.ConnectionType, privateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	bodyRequest := &client.VcsEntity{
 		ID:             plan.ID.ValueString(),
 		Name:           plan.Name.ValueString(),
@@ -364,8 +452,8 @@ func (r *VcsResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		VcsType:        plan.VcsType.ValueString(),
 		ConnectionType: plan.ConnectionType.ValueString(),
 		ClientId:       plan.ClientId.ValueString(),
-		ClientSecret:   plan.ClientSecret.ValueString(),
-		PrivateKey:     plan.PrivateKey.ValueString(),
+		ClientSecret:   clientSecret.ValueString(),
+		PrivateKey:     privateKey.ValueString(),
 		Endpoint:       plan.Endpoint.ValueString(),
 		ApiUrl:         plan.ApiUrl.ValueString(),
 		Status:         plan.Status.ValueString(),
@@ -454,6 +542,7 @@ func (r *VcsResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *VcsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -485,13 +574,34 @@ func (r *VcsResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 	}
 }
 
+func (r *VcsResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema("organization_id", "id")
+}
+
 func (r *VcsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importStateFromIdentity(ctx, req, resp) {
+		return
+	}
+
+	steps := []importStep{organizationImportStep, {
+		attribute:  "id",
+		kind:       "VCS",
+		collection: "organization/%s/vcs",
+		filter:     "vcs",
+		fields:     []string{"name"},
+		entity:     new(client.VcsEntity),
+	}}
+	api := importAPI{client: r.client, endpoint: r.endpoint, token: r.token}
+	if api.importByName(ctx, req, resp, steps...) {
+		return
+	}
+
 	idParts := strings.Split(req.ID, ",")
 
 	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: 'organization_ID,ID', Got: %q", req.ID),
+			fmt.Sprintf("Expected import identifier with format: 'organization_ID,ID' or '%s', Got: %q", importNamesFormat(steps...), req.ID),
 		)
 		return
 	}
@@ -500,6 +610,17 @@ func (r *VcsResource) ImportState(ctx context.Context, req resource.ImportStateR
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[1])...)
 }
 
+// vcsSecretAtLeastOneOf requires one of the VCS connection secrets, in either
+// their regular or write-only form.
+func vcsSecretAtLeastOneOf() validator.String {
+	return stringvalidator.AtLeastOneOf(
+		path.MatchRelative().AtParent().AtName("client_secret"),
+		path.MatchRelative().AtParent().AtName("private_key"),
+		path.MatchRelative().AtParent().AtName("client_secret_wo"),
+		path.MatchRelative().AtParent().AtName("private_key_wo"),
+	)
+}
+
 func GetEndpointAndApiUrl(vcs_type string, clientId string, supplied_endpoint string) (string, string, string) {
 	var endpoint, api_url, connect_url string
 	switch vcs_type {
@@ -569,5 +690,32 @@ func (r VcsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequ
 	}
 	plan.ConnectUrl = types.StringValue(connectUrl)
 
+	privateKey, diags := secretValue(ctx, req.Config, plan.PrivateKey, "private_key_wo")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.PrivateKeyFingerprint = vcsPrivateKeyFingerprintValue(plan.ConnectionType, privateKey)
+
 	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
 }
+
+func (r *VcsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config VcsResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.ConnectionType.Equal(types.StringValue("STANDALONE")) {
+		return
+	}
+	for name, privateKey := range map[string]types.String{"private_key": config.PrivateKey, "private_key_wo": config.PrivateKeyWo} {
+		if privateKey.IsNull() || privateKey.IsUnknown() {
+			continue
+		}
+		if _, _, err := normalizeVcsPrivateKey(privateKey.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(name), "Invalid Private Key", fmt.Sprintf("The private key of a STANDALONE VCS connection must be an unencrypted PEM key: %s.", err))
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &WebhookFilterMatchesFunction{}
+
+type WebhookFilterMatchesFunction struct{}
+
+func NewWebhookFilterMatchesFunction() function.Function {
+	return &WebhookFilterMatchesFunction{}
+}
+
+func (f *WebhookFilterMatchesFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "webhook_filter_matches"
+}
+
+func (f *WebhookFilterMatchesFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Evaluate webhook event branch or path filters",
+		MarkdownDescription: "Returns the `values` matched by at least one of the `filters`, in their original order. Filters are " +
+			"evaluated the way Terrakube evaluates the `branch` and `path` of a webhook event, as regular expressions matched " +
+			"against the whole branch name or changed file, so trigger rules can be checked with `terraform test` before they " +
+			"are pushed. Filters using Java only features, like look arounds, back references or possessive quantifiers, return an error.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:        "filters",
+				Description: "Branch or path filters of a webhook event.",
+				ElementType: types.StringType,
+			},
+			function.ListParameter{
+				Name:        "values",
+				Description: "Sample branch names or changed files.",
+				ElementType: types.StringType,
+			},
+		},
+		Return: function.ListReturn{ElementType: types.StringType},
+	}
+}
+
+func (f *WebhookFilterMatchesFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var filters, values []string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &filters, &values))
+	if resp.Error != nil {
+		return
+	}
+
+	matches, err := webhookEventFilterMatches(filters, values)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Error evaluating filters: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, matches))
+}
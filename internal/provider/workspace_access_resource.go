@@ -27,6 +27,7 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &WorkspaceAccessResource{}
+var _ resource.ResourceWithIdentity = &WorkspaceAccessResource{}
 var _ resource.ResourceWithImportState = &WorkspaceAccessResource{}
 var _ resource.ResourceWithConfigValidators = &WorkspaceAccessResource{}
 
@@ -246,9 +247,12 @@ func (r *WorkspaceAccessResource) Create(ctx context.Context, req resource.Creat
 	tflog.Info(ctx, "workspace access Created", map[string]any{"success": true})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *WorkspaceAccessResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.Diagnostics.Append(setResourceIdentity(ctx, req.State, resp.Identity)...)
+
 	var state WorkspaceAccessResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -435,6 +439,7 @@ func (r *WorkspaceAccessResource) Update(ctx context.Context, req resource.Updat
 	plan.Name = types.StringValue(workspaceAccess.Name)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *WorkspaceAccessResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -462,13 +467,34 @@ func (r *WorkspaceAccessResource) Delete(ctx context.Context, req resource.Delet
 	defer delResp.Body.Close()
 }
 
+func (r *WorkspaceAccessResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema("organization_id", "workspace_id", "id")
+}
+
 func (r *WorkspaceAccessResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importStateFromIdentity(ctx, req, resp) {
+		return
+	}
+
+	steps := []importStep{organizationImportStep, workspaceImportStep, {
+		attribute:  "id",
+		kind:       "Team",
+		collection: "organization/%s/workspace/%s/access",
+		filter:     "access",
+		fields:     []string{"name"},
+		entity:     new(client.WorkspaceAccessEntity),
+	}}
+	api := importAPI{client: r.client, endpoint: r.endpoint, token: r.token}
+	if api.importByName(ctx, req, resp, steps...) {
+		return
+	}
+
 	idParts := strings.Split(req.ID, ",")
 
 	if len(idParts) != 3 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: 'organization_ID,workspace_ID,ID', Got: %q", req.ID),
+			fmt.Sprintf("Expected import identifier with format: 'organization_ID,workspace_ID,ID' or '%s', Got: %q", importNamesFormat(steps...), req.ID),
 		)
 		return
 	}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &WorkspaceImportIDFunction{}
+
+type WorkspaceImportIDFunction struct{}
+
+func NewWorkspaceImportIDFunction() function.Function {
+	return &WorkspaceImportIDFunction{}
+}
+
+func (f *WorkspaceImportIDFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "workspace_import_id"
+}
+
+func (f *WorkspaceImportIDFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build the import identifier of a workspace",
+		MarkdownDescription: "Returns the `organization_id,workspace_id` import identifier of a workspace, as used by `terrakube_workspace_cli` and `terrakube_workspace_vcs`. " +
+			"Any further ids, like the id of a workspace variable, are appended in order.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "organization_id",
+				Description: "Terrakube organization id.",
+			},
+			function.StringParameter{
+				Name:        "workspace_id",
+				Description: "Terrakube workspace id.",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:        "ids",
+			Description: "Ids of the entities nested under the workspace.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *WorkspaceImportIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var organizationID, workspaceID string
+	var ids []string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &organizationID, &workspaceID, &ids))
+	if resp.Error != nil {
+		return
+	}
+
+	parts := append([]string{organizationID, workspaceID}, ids...)
+	for i, part := range parts {
+		if part == "" || strings.Contains(part, ",") {
+			resp.Error = function.NewArgumentFuncError(int64(min(i, 2)), "Ids must not be empty or contain commas.")
+			return
+		}
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, strings.Join(parts, ",")))
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -28,7 +29,10 @@ import (
 )
 
 var _ resource.Resource = &WorkspaceNotificationConfigurationResource{}
+var _ resource.ResourceWithIdentity = &WorkspaceNotificationConfigurationResource{}
 var _ resource.ResourceWithImportState = &WorkspaceNotificationConfigurationResource{}
+var _ resource.ResourceWithModifyPlan = &WorkspaceNotificationConfigurationResource{}
+var _ resource.ResourceWithValidateConfig = &WorkspaceNotificationConfigurationResource{}
 
 type WorkspaceNotificationConfigurationResource struct {
 	client   *http.Client
@@ -37,18 +41,22 @@ type WorkspaceNotificationConfigurationResource struct {
 }
 
 type WorkspaceNotificationConfigurationResourceModel struct {
-	ID              types.String `tfsdk:"id"`
-	OrganizationId  types.String `tfsdk:"organization_id"`
-	WorkspaceId     types.String `tfsdk:"workspace_id"`
-	Name            types.String `tfsdk:"name"`
-	Description     types.String `tfsdk:"description"`
-	ChannelType     types.String `tfsdk:"channel_type"`
-	DestinationUrl  types.String `tfsdk:"destination_url"`
-	SigningSecret   types.String `tfsdk:"signing_secret"`
-	Active          types.Bool   `tfsdk:"active"`
-	MessageStyle    types.String `tfsdk:"message_style"`
-	TriggerStatuses types.List   `tfsdk:"trigger_statuses"`
-	TemplateIds     types.List   `tfsdk:"template_ids"`
+	ID               types.String `tfsdk:"id"`
+	OrganizationId   types.String `tfsdk:"organization_id"`
+	WorkspaceId      types.String `tfsdk:"workspace_id"`
+	Name             types.String `tfsdk:"name"`
+	Description      types.String `tfsdk:"description"`
+	ChannelType      types.String `tfsdk:"channel_type"`
+	DestinationUrl   types.String `tfsdk:"destination_url"`
+	SigningSecret    types.String `tfsdk:"signing_secret"`
+	Active           types.Bool   `tfsdk:"active"`
+	MessageStyle     types.String `tfsdk:"message_style"`
+	TriggerStatuses  types.List   `tfsdk:"trigger_statuses"`
+	TemplateIds      types.List   `tfsdk:"template_ids"`
+	BodyTemplate     types.String `tfsdk:"body_template"`
+	Headers          types.Map    `tfsdk:"headers"`
+	RoutingKey       types.String `tfsdk:"routing_key"`
+	SendTestOnChange types.Bool   `tfsdk:"send_test_on_change"`
 }
 
 func NewWorkspaceNotificationConfigurationResource() resource.Resource {
@@ -89,19 +97,20 @@ func (r *WorkspaceNotificationConfigurationResource) Schema(ctx context.Context,
 			},
 			"channel_type": schema.StringAttribute{
 				Required:    true,
-				Description: "Delivery channel. Valid values: `SLACK`, `TEAMS`, `WEBHOOK`.",
+				Description: "Delivery channel. Valid values: `SLACK`, `TEAMS`, `TEAMS_WORKFLOW`, `WEBHOOK`, `EMAIL`, `PAGERDUTY`. Channels other than `SLACK`, `TEAMS` and `WEBHOOK` require a Terrakube release supporting them; older instances will reject them.",
 				Validators: []validator.String{
-					stringvalidator.OneOf("SLACK", "TEAMS", "WEBHOOK"),
+					stringvalidator.OneOf(notificationChannelTypes...),
 				},
 			},
 			"destination_url": schema.StringAttribute{
 				Required:    true,
-				Description: "Destination URL for the channel (Slack/Teams incoming webhook URL, or the target URL for a generic webhook).",
+				Description: "Destination URL for the channel: the Slack or Teams incoming webhook URL, the Teams Workflows trigger URL for `TEAMS_WORKFLOW`, the target URL for a generic webhook, the PagerDuty Events API URL for `PAGERDUTY`, or a `mailto:` URL with comma separated addresses for `EMAIL`.",
 			},
 			"signing_secret": schema.StringAttribute{
 				Optional:    true,
+				Computed:    true,
 				Sensitive:   true,
-				Description: "If set, outgoing WEBHOOK requests are signed with an X-Terrakube-Signature header (HMAC-SHA256) so the destination can verify they came from Terrakube.",
+				Description: "If set, outgoing WEBHOOK requests are signed with an X-Terrakube-Signature header (HMAC-SHA256) so the destination can verify they came from Terrakube. When omitted for a `WEBHOOK` channel, the provider generates a random secret on create and keeps it. Removing a configured secret, or changing `channel_type` from `WEBHOOK`, clears it.",
 			},
 			"active": schema.BoolAttribute{
 				Optional:    true,
@@ -133,10 +142,63 @@ func (r *WorkspaceNotificationConfigurationResource) Schema(ctx context.Context,
 				Default:     listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
 				Description: "Template IDs this configuration is narrowed to. Empty (the default) means it applies to every template - this list only ever narrows, it never widens beyond that.",
 			},
+			"body_template": schema.StringAttribute{
+				Optional:    true,
+				Description: "Custom body of `WEBHOOK` requests, replacing the default payload. `{{field}}` placeholders are replaced with the fields of the payload: `organizationId`, `organization`, `workspaceId`, `workspace`, `jobId`, `jobStatus`, `commitId`, `url`, `triggeredBy` and `timestamp`.",
+			},
+			"headers": schema.MapAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+				Description: "Extra headers of `WEBHOOK` requests, for example the `Authorization` header of an incident tool.",
+			},
+			"routing_key": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Routing key of the PagerDuty service integration, required for `PAGERDUTY`.",
+			},
+			"send_test_on_change": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Send a test notification to `destination_url` before the configuration is created or updated, and fail the apply without changing it when the destination doesn't accept it, so broken Slack, Teams or webhook URLs are caught at apply time. Terrakube has no API to send test notifications, so the provider sends it itself from the machine running Terraform, which must be able to reach the destination, signed with `signing_secret` and with the `headers` and `body_template` of a `WEBHOOK` channel. `EMAIL` and `PAGERDUTY` configurations can't be tested and only get a warning.",
+			},
 		},
 	}
 }
 
+func (r *WorkspaceNotificationConfigurationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config WorkspaceNotificationConfigurationResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateNotificationChannel(ctx, notificationChannelConfig{
+		ChannelType:    config.ChannelType,
+		DestinationUrl: config.DestinationUrl,
+		BodyTemplate:   config.BodyTemplate,
+		Headers:        config.Headers,
+		RoutingKey:     config.RoutingKey,
+	})...)
+}
+
+func (r *WorkspaceNotificationConfigurationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifySigningSecretPlan(ctx, req, resp)
+}
+
+// sendTestNotification sends the test notification of send_test_on_change.
+func (m WorkspaceNotificationConfigurationResourceModel) sendTestNotification(ctx context.Context) diag.Diagnostics {
+	channel := notificationChannelConfig{
+		ChannelType:    m.ChannelType,
+		DestinationUrl: m.DestinationUrl,
+		BodyTemplate:   m.BodyTemplate,
+		Headers:        m.Headers,
+		RoutingKey:     m.RoutingKey,
+	}
+	return sendTestNotification(ctx, m.Name.ValueString(), channel, m.SigningSecret, notificationTestPayload(m.OrganizationId.ValueString(), m.WorkspaceId.ValueString()))
+}
+
 func (r *WorkspaceNotificationConfigurationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -189,6 +251,25 @@ func (r *WorkspaceNotificationConfigurationResource) Create(ctx context.Context,
 		}
 	}
 
+	generatedSecret := plan.SigningSecret.IsUnknown() && plan.ChannelType.ValueString() == "WEBHOOK"
+	signingSecret, secretDiags := resolveSigningSecret(plan.ChannelType.ValueString(), plan.SigningSecret)
+	resp.Diagnostics.Append(secretDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.SigningSecret = signingSecret
+	headers, headerDiags := notificationHeaders(ctx, plan.Headers, types.MapNull(types.StringType))
+	resp.Diagnostics.Append(headerDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.SendTestOnChange.ValueBool() {
+		resp.Diagnostics.Append(plan.sendTestNotification(ctx)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	bodyRequest := &client.NotificationConfigurationEntity{
 		Name:           plan.Name.ValueString(),
 		Description:    plan.Description.ValueStringPointer(),
@@ -197,6 +278,9 @@ func (r *WorkspaceNotificationConfigurationResource) Create(ctx context.Context,
 		SigningSecret:  plan.SigningSecret.ValueStringPointer(),
 		Active:         plan.Active.ValueBool(),
 		MessageStyle:   plan.MessageStyle.ValueString(),
+		BodyTemplate:   plan.BodyTemplate.ValueStringPointer(),
+		Headers:        headers,
+		RoutingKey:     plan.RoutingKey.ValueStringPointer(),
 	}
 
 	out := new(bytes.Buffer)
@@ -251,9 +335,14 @@ func (r *WorkspaceNotificationConfigurationResource) Create(ctx context.Context,
 	plan.Description = optionalStringValue(created.Description)
 	plan.ChannelType = types.StringValue(created.ChannelType)
 	plan.DestinationUrl = types.StringValue(created.DestinationUrl)
-	plan.SigningSecret = optionalStringValue(created.SigningSecret)
+	plan.SigningSecret = clearableStringValue(created.SigningSecret)
 	plan.Active = types.BoolValue(created.Active)
 	plan.MessageStyle = types.StringValue(created.MessageStyle)
+	plan.BodyTemplate = clearableStringValue(created.BodyTemplate)
+	plan.RoutingKey = clearableStringValue(created.RoutingKey)
+	createdHeaders, headersDiags := notificationHeadersValue(ctx, created.Headers)
+	resp.Diagnostics.Append(headersDiags...)
+	plan.Headers = createdHeaders
 	triggerList, listDiags := types.ListValueFrom(ctx, types.StringType, triggerStatuses)
 	resp.Diagnostics.Append(listDiags...)
 	plan.TriggerStatuses = triggerList
@@ -262,10 +351,16 @@ func (r *WorkspaceNotificationConfigurationResource) Create(ctx context.Context,
 	plan.TemplateIds = templateList
 
 	tflog.Info(ctx, "Workspace Notification Configuration Resource Created", map[string]any{"success": true})
+	if generatedSecret {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, notificationSecretGeneratedKey, []byte("true"))...)
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *WorkspaceNotificationConfigurationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.Diagnostics.Append(setResourceIdentity(ctx, req.State, resp.Identity)...)
+
 	var state WorkspaceNotificationConfigurationResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -330,11 +425,19 @@ func (r *WorkspaceNotificationConfigurationResource) Read(ctx context.Context, r
 	state.Description = optionalStringValue(configuration.Description)
 	state.ChannelType = types.StringValue(configuration.ChannelType)
 	state.DestinationUrl = types.StringValue(configuration.DestinationUrl)
-	state.SigningSecret = optionalStringValue(configuration.SigningSecret)
+	state.SigningSecret = clearableStringValue(configuration.SigningSecret)
 	state.Active = types.BoolValue(configuration.Active)
 	state.MessageStyle = types.StringValue(configuration.MessageStyle)
+	state.BodyTemplate = clearableStringValue(configuration.BodyTemplate)
+	state.RoutingKey = clearableStringValue(configuration.RoutingKey)
+	headers, headersDiags := notificationHeadersValue(ctx, configuration.Headers)
+	resp.Diagnostics.Append(headersDiags...)
+	state.Headers = headers
 	state.TriggerStatuses = triggerList
 	state.TemplateIds = templateList
+	if state.SendTestOnChange.IsNull() {
+		state.SendTestOnChange = types.BoolValue(false)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -361,15 +464,38 @@ func (r *WorkspaceNotificationConfigurationResource) Update(ctx context.Context,
 		}
 	}
 
+	if plan.SigningSecret.IsUnknown() {
+		generated, diags := req.Private.GetKey(ctx, notificationSecretGeneratedKey)
+		resp.Diagnostics.Append(diags...)
+		plan.SigningSecret = plannedSigningSecret(plan.ChannelType, state.SigningSecret, generated != nil)
+	}
+	if !plan.SigningSecret.Equal(state.SigningSecret) {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, notificationSecretGeneratedKey, nil)...)
+	}
+	headers, headerDiags := notificationHeaders(ctx, plan.Headers, state.Headers)
+	resp.Diagnostics.Append(headerDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.SendTestOnChange.ValueBool() {
+		resp.Diagnostics.Append(plan.sendTestNotification(ctx)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	bodyRequest := &client.NotificationConfigurationEntity{
 		ID:             state.ID.ValueString(),
 		Name:           plan.Name.ValueString(),
 		Description:    plan.Description.ValueStringPointer(),
 		ChannelType:    plan.ChannelType.ValueString(),
 		DestinationUrl: plan.DestinationUrl.ValueString(),
-		SigningSecret:  plan.SigningSecret.ValueStringPointer(),
+		SigningSecret:  clearableStringRequestValue(plan.SigningSecret, state.SigningSecret),
 		Active:         plan.Active.ValueBool(),
 		MessageStyle:   plan.MessageStyle.ValueString(),
+		BodyTemplate:   clearableStringRequestValue(plan.BodyTemplate, state.BodyTemplate),
+		Headers:        headers,
+		RoutingKey:     clearableStringRequestValue(plan.RoutingKey, state.RoutingKey),
 	}
 
 	out := new(bytes.Buffer)
@@ -427,6 +553,7 @@ func (r *WorkspaceNotificationConfigurationResource) Update(ctx context.Context,
 	plan.TemplateIds = templateList
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *WorkspaceNotificationConfigurationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -450,12 +577,33 @@ func (r *WorkspaceNotificationConfigurationResource) Delete(ctx context.Context,
 	}
 }
 
+func (r *WorkspaceNotificationConfigurationResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema("organization_id", "workspace_id", "id")
+}
+
 func (r *WorkspaceNotificationConfigurationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importStateFromIdentity(ctx, req, resp) {
+		return
+	}
+
+	steps := []importStep{organizationImportStep, workspaceImportStep, {
+		attribute:  "id",
+		kind:       "Notification configuration",
+		collection: "organization/%s/workspace/%s/notificationConfiguration",
+		filter:     "notification_configuration",
+		fields:     []string{"name"},
+		entity:     new(client.NotificationConfigurationEntity),
+	}}
+	api := importAPI{client: r.client, endpoint: r.endpoint, token: r.token}
+	if api.importByName(ctx, req, resp, steps...) {
+		return
+	}
+
 	idParts := strings.Split(req.ID, ",")
 	if len(idParts) != 3 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: 'organization_ID,workspace_ID,ID', Got: %q", req.ID),
+			fmt.Sprintf("Expected import identifier with format: 'organization_ID,workspace_ID,ID' or '%s', Got: %q", importNamesFormat(steps...), req.ID),
 		)
 		return
 	}
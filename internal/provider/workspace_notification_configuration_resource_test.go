@@ -189,3 +189,83 @@ func TestWorkspaceNotificationConfigurationResource_Update_ReplacesTemplatesWhol
 		t.Errorf("expected templates PATCH body to NOT contain tmpl-old (full replace, not merge), got: %s", templatesPatchBody)
 	}
 }
+
+func TestWorkspaceNotificationConfigurationResource_Update_ClearsRemovedSettings(t *testing.T) {
+	ctx := context.Background()
+	s, objType := workspaceNotificationConfigurationSchemaAndType(t, ctx)
+
+	var patchBody string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/notification_configuration/cfg-1", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("reading request body: %v", err)
+		}
+		patchBody = string(body)
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/api/v1/notification_configuration/cfg-1/triggers", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[]}`)
+	})
+	mux.HandleFunc("/api/v1/notification_configuration/cfg-1/relationships/templates", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	r := &WorkspaceNotificationConfigurationResource{
+		client:   server.Client(),
+		endpoint: server.URL,
+		token:    "test-token",
+	}
+
+	base := map[string]tftypes.Value{
+		"id":                  tftypes.NewValue(tftypes.String, "cfg-1"),
+		"organization_id":     tftypes.NewValue(tftypes.String, "org-1"),
+		"workspace_id":        tftypes.NewValue(tftypes.String, "ws-1"),
+		"name":                tftypes.NewValue(tftypes.String, "prod-alerts"),
+		"active":              tftypes.NewValue(tftypes.Bool, true),
+		"message_style":       tftypes.NewValue(tftypes.String, "DETAILED"),
+		"send_test_on_change": tftypes.NewValue(tftypes.Bool, false),
+		"trigger_statuses":    tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{}),
+	}
+	with := func(values map[string]tftypes.Value) tftypes.Value {
+		for k, v := range base {
+			values[k] = v
+		}
+		return buildObjectValue(objType, values)
+	}
+
+	stateValue := with(map[string]tftypes.Value{
+		"channel_type":    tftypes.NewValue(tftypes.String, "PAGERDUTY"),
+		"destination_url": tftypes.NewValue(tftypes.String, "https://events.pagerduty.com/v2/enqueue"),
+		"routing_key":     tftypes.NewValue(tftypes.String, "routing-key"),
+		"body_template":   tftypes.NewValue(tftypes.String, `{"summary":"{{workspaceName}}"}`),
+		"headers": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"X-Team": tftypes.NewValue(tftypes.String, "platform"),
+		}),
+	})
+	planValue := with(map[string]tftypes.Value{
+		"channel_type":    tftypes.NewValue(tftypes.String, "SLACK"),
+		"destination_url": tftypes.NewValue(tftypes.String, "https://hooks.slack.com/x"),
+	})
+
+	req := resource.UpdateRequest{
+		State: tfsdk.State{Schema: s, Raw: stateValue},
+		Plan:  tfsdk.Plan{Schema: s, Raw: planValue},
+	}
+	resp := &resource.UpdateResponse{State: tfsdk.State{Schema: s}}
+
+	r.Update(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	for _, cleared := range []string{`"routingKey":""`, `"bodyTemplate":""`, `"headers":{}`} {
+		if !contains(patchBody, cleared) {
+			t.Errorf("expected the PATCH body to clear %s, got: %s", cleared, patchBody)
+		}
+	}
+}
@@ -4,15 +4,26 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 	"terraform-provider-terrakube/internal/client"
+	"terraform-provider-terrakube/internal/quartz"
+	"time"
 
 	"github.com/google/jsonapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -23,7 +34,20 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &WorkspaceScheduleResource{}
+var _ resource.ResourceWithIdentity = &WorkspaceScheduleResource{}
 var _ resource.ResourceWithImportState = &WorkspaceScheduleResource{}
+var _ resource.ResourceWithModifyPlan = &WorkspaceScheduleResource{}
+var _ resource.ResourceWithValidateConfig = &WorkspaceScheduleResource{}
+
+const (
+	defaultScheduleTimezone      = "UTC"
+	defaultScheduleNextRunsCount = 5
+)
+
+var scheduleWindowAttrTypes = map[string]attr.Type{
+	"start": types.StringType,
+	"end":   types.StringType,
+}
 
 type WorkspaceScheduleResource struct {
 	client   *http.Client
@@ -32,10 +56,15 @@ type WorkspaceScheduleResource struct {
 }
 
 type WorkspaceScheduleResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	WorkspaceId types.String `tfsdk:"workspace_id"`
-	TemplateId  types.String `tfsdk:"template_id"`
-	Schedule    types.String `tfsdk:"schedule"`
+	ID            types.String `tfsdk:"id"`
+	WorkspaceId   types.String `tfsdk:"workspace_id"`
+	TemplateId    types.String `tfsdk:"template_id"`
+	Schedule      types.String `tfsdk:"schedule"`
+	Enabled       types.Bool   `tfsdk:"enabled"`
+	Timezone      types.String `tfsdk:"timezone"`
+	Window        types.Object `tfsdk:"window"`
+	NextRunsCount types.Int64  `tfsdk:"next_runs_count"`
+	NextRuns      types.List   `tfsdk:"next_runs"`
 }
 
 func NewWorkspaceScheduleResource() resource.Resource {
@@ -61,6 +90,59 @@ func (r *WorkspaceScheduleResource) Schema(ctx context.Context, req resource.Sch
 			"schedule": schema.StringAttribute{
 				Required:    true,
 				Description: "Schedule expression using java quartz notation",
+				Validators: []validator.String{
+					quartzCronValidator{},
+				},
+			},
+			"timezone": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(defaultScheduleTimezone),
+				Description: fmt.Sprintf("IANA time zone the schedule is evaluated in, for example `Europe/Madrid`. Default `%s`.", defaultScheduleTimezone),
+				Validators: []validator.String{
+					timeZoneValidator{},
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether the schedule triggers jobs. Set it to `false` to pause the schedule without destroying it. Default `true`.",
+			},
+			"window": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Period the schedule is active in. Runs before `start` or after `end` are skipped.",
+				Attributes: map[string]schema.Attribute{
+					"start": schema.StringAttribute{
+						Optional:    true,
+						Description: "First moment the schedule can fire, in RFC 3339 format.",
+						Validators: []validator.String{
+							rfc3339Validator{},
+							stringvalidator.AtLeastOneOf(path.MatchRelative().AtParent().AtName("end")),
+						},
+					},
+					"end": schema.StringAttribute{
+						Optional:    true,
+						Description: "Last moment the schedule can fire, in RFC 3339 format.",
+						Validators: []validator.String{
+							rfc3339Validator{},
+						},
+					},
+				},
+			},
+			"next_runs_count": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(defaultScheduleNextRunsCount),
+				Description: fmt.Sprintf("Number of upcoming fire times to list in `next_runs`. Default `%d`.", defaultScheduleNextRunsCount),
+				Validators: []validator.Int64{
+					int64validator.Between(0, 100),
+				},
+			},
+			"next_runs": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The next times the schedule fires, in RFC 3339 format and in the configured `timezone`. Empty while the schedule is disabled. They are computed when the schedule is created or changed, not refreshed as time passes.",
 			},
 			"template_id": schema.StringAttribute{
 				Required:    true,
@@ -116,14 +198,13 @@ func (r *WorkspaceScheduleResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
-	bodyRequest := &client.WorkspaceScheduleEntity{
-		Schedule:   plan.Schedule.ValueString(),
-		TemplateId: plan.TemplateId.ValueString(),
+	var config WorkspaceScheduleResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	var out = new(bytes.Buffer)
-	err := jsonapi.MarshalPayload(out, bodyRequest)
-
+	out, err := workspaceSchedulePayload(plan, config, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to marshal payload", fmt.Sprintf("Unable to marshal payload: %s", err))
 		return
@@ -167,9 +248,12 @@ func (r *WorkspaceScheduleResource) Create(ctx context.Context, req resource.Cre
 	tflog.Info(ctx, "workspace schedule Resource Created", map[string]any{"success": true})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *WorkspaceScheduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.Diagnostics.Append(setResourceIdentity(ctx, req.State, resp.Identity)...)
+
 	var state WorkspaceScheduleResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -216,6 +300,30 @@ func (r *WorkspaceScheduleResource) Read(ctx context.Context, req resource.ReadR
 	state.Schedule = types.StringValue(workspaceSchedule.Schedule)
 	state.TemplateId = types.StringValue(workspaceSchedule.TemplateId)
 	state.ID = types.StringValue(workspaceSchedule.ID)
+	// Servers that predate these attributes leave them out, keep what we have.
+	if workspaceSchedule.Enabled != nil {
+		state.Enabled = types.BoolValue(*workspaceSchedule.Enabled)
+	} else if state.Enabled.IsNull() {
+		state.Enabled = types.BoolValue(true)
+	}
+	if workspaceSchedule.Timezone != "" {
+		state.Timezone = types.StringValue(workspaceSchedule.Timezone)
+	} else if state.Timezone.IsNull() {
+		state.Timezone = types.StringValue(defaultScheduleTimezone)
+	}
+	state.Window = refreshScheduleWindow(state.Window, workspaceSchedule.StartDate, workspaceSchedule.EndDate)
+	if state.NextRunsCount.IsNull() {
+		state.NextRunsCount = types.Int64Value(defaultScheduleNextRunsCount)
+	}
+	// The fire times are computed when the schedule is planned, refreshing
+	// them here would drift with the clock. Only an import has none yet.
+	if state.NextRuns.IsNull() {
+		state.NextRuns, diags = scheduleNextRuns(state, time.Now())
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -237,15 +345,13 @@ func (r *WorkspaceScheduleResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
-	bodyRequest := &client.WorkspaceScheduleEntity{
-		Schedule:   plan.Schedule.ValueString(),
-		TemplateId: plan.TemplateId.ValueString(),
-		ID:         state.ID.ValueString(),
+	var config WorkspaceScheduleResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	var out = new(bytes.Buffer)
-	err := jsonapi.MarshalPayload(out, bodyRequest)
-
+	out, err := workspaceSchedulePayload(plan, config, &state)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to marshal payload", fmt.Sprintf("Unable to marshal payload: %s", err))
 		return
@@ -306,6 +412,7 @@ func (r *WorkspaceScheduleResource) Update(ctx context.Context, req resource.Upd
 	plan.TemplateId = types.StringValue(workspaceSchedule.TemplateId)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *WorkspaceScheduleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -332,18 +439,290 @@ func (r *WorkspaceScheduleResource) Delete(ctx context.Context, req resource.Del
 	}
 }
 
+func (r *WorkspaceScheduleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Do nothing if it's destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan WorkspaceScheduleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Schedule.IsUnknown() || plan.Enabled.IsUnknown() || plan.Timezone.IsUnknown() || !scheduleWindowKnown(plan.Window) || plan.NextRunsCount.IsUnknown() {
+		plan.NextRuns = types.ListUnknown(types.StringType)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+
+	// Keep the planned fire times unless the schedule itself changes, so the
+	// passing of time alone never shows up as a diff.
+	if !req.State.Raw.IsNull() {
+		var state WorkspaceScheduleResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !state.NextRuns.IsNull() && plan.Schedule.Equal(state.Schedule) && plan.Enabled.Equal(state.Enabled) && plan.Timezone.Equal(state.Timezone) &&
+			plan.Window.Equal(state.Window) && plan.NextRunsCount.Equal(state.NextRunsCount) {
+			plan.NextRuns = state.NextRuns
+			resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+			return
+		}
+	}
+
+	nextRuns, diags := scheduleNextRuns(plan, time.Now())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.NextRuns = nextRuns
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *WorkspaceScheduleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config WorkspaceScheduleResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || !scheduleWindowKnown(config.Window) {
+		return
+	}
+
+	start, end := scheduleWindowDates(config.Window)
+	if start == nil || end == nil {
+		return
+	}
+
+	startTime, startErr := time.Parse(time.RFC3339, *start)
+	endTime, endErr := time.Parse(time.RFC3339, *end)
+	if startErr == nil && endErr == nil && !startTime.Before(endTime) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("window").AtName("end"),
+			"Invalid Schedule Window",
+			fmt.Sprintf("The window end %s must be after its start %s.", *end, *start),
+		)
+	}
+}
+
+// scheduleNextRuns lists the next fire times of a workspace schedule after
+// now, formatted in its time zone. Runs outside the window are skipped and a
+// disabled schedule never fires.
+func scheduleNextRuns(schedule WorkspaceScheduleResourceModel, now time.Time) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !schedule.Enabled.IsNull() && !schedule.Enabled.ValueBool() {
+		return types.ListValueMust(types.StringType, []attr.Value{}), diags
+	}
+
+	expression, err := quartz.Parse(schedule.Schedule.ValueString())
+	if err != nil {
+		diags.AddError("Error parsing schedule", fmt.Sprintf("Error parsing schedule %q: %s", schedule.Schedule.ValueString(), err))
+		return types.ListNull(types.StringType), diags
+	}
+
+	location, err := time.LoadLocation(schedule.Timezone.ValueString())
+	if err != nil {
+		diags.AddError("Error loading time zone", fmt.Sprintf("Error loading time zone %q: %s", schedule.Timezone.ValueString(), err))
+		return types.ListNull(types.StringType), diags
+	}
+
+	var windowStart, windowEnd time.Time
+	start, end := scheduleWindowDates(schedule.Window)
+	if start != nil {
+		if windowStart, err = time.Parse(time.RFC3339, *start); err != nil {
+			diags.AddError("Error parsing schedule window", fmt.Sprintf("Error parsing schedule window start %q: %s", *start, err))
+			return types.ListNull(types.StringType), diags
+		}
+	}
+	if end != nil {
+		if windowEnd, err = time.Parse(time.RFC3339, *end); err != nil {
+			diags.AddError("Error parsing schedule window", fmt.Sprintf("Error parsing schedule window end %q: %s", *end, err))
+			return types.ListNull(types.StringType), diags
+		}
+	}
+
+	after := now.In(location)
+	// The window start itself is a valid fire time.
+	if !windowStart.IsZero() && windowStart.After(after) {
+		after = windowStart.Add(-time.Second).In(location)
+	}
+
+	runs := []attr.Value{}
+	for int64(len(runs)) < schedule.NextRunsCount.ValueInt64() {
+		next := expression.Next(after)
+		if next.IsZero() || (!windowEnd.IsZero() && next.After(windowEnd)) {
+			break
+		}
+		runs = append(runs, types.StringValue(next.Format(time.RFC3339)))
+		after = next
+	}
+
+	return types.ListValue(types.StringType, runs)
+}
+
+// scheduleWindowKnown reports whether the window and its dates are all known.
+func scheduleWindowKnown(window types.Object) bool {
+	if window.IsUnknown() {
+		return false
+	}
+	for _, value := range window.Attributes() {
+		if value.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
+
+// scheduleWindowDates returns the window start and end as sent to the API,
+// nil when they're not set.
+func scheduleWindowDates(window types.Object) (*string, *string) {
+	if window.IsNull() || window.IsUnknown() {
+		return nil, nil
+	}
+
+	attributes := window.Attributes()
+	start, _ := attributes["start"].(types.String)
+	end, _ := attributes["end"].(types.String)
+	return start.ValueStringPointer(), end.ValueStringPointer()
+}
+
+// workspaceSchedulePayload builds the request body of a schedule. Terrakube
+// releases that predate enabled, timezone and window reject them, so they are
+// only sent when set in config or changed from the prior state. Dates removed
+// from the window are sent as null to clear them.
+func workspaceSchedulePayload(plan, config WorkspaceScheduleResourceModel, prior *WorkspaceScheduleResourceModel) (*bytes.Buffer, error) {
+	bodyRequest := &client.WorkspaceScheduleEntity{
+		Schedule:   plan.Schedule.ValueString(),
+		TemplateId: plan.TemplateId.ValueString(),
+	}
+	if prior != nil {
+		bodyRequest.ID = prior.ID.ValueString()
+	}
+	if !config.Enabled.IsNull() || (prior != nil && !plan.Enabled.Equal(prior.Enabled)) {
+		bodyRequest.Enabled = plan.Enabled.ValueBoolPointer()
+	}
+	if !config.Timezone.IsNull() || (prior != nil && !plan.Timezone.Equal(prior.Timezone)) {
+		bodyRequest.Timezone = plan.Timezone.ValueString()
+	}
+	bodyRequest.StartDate, bodyRequest.EndDate = scheduleWindowDates(plan.Window)
+
+	var out = new(bytes.Buffer)
+	if err := jsonapi.MarshalPayload(out, bodyRequest); err != nil {
+		return nil, err
+	}
+	if prior == nil {
+		return out, nil
+	}
+
+	var cleared []string
+	priorStart, priorEnd := scheduleWindowDates(prior.Window)
+	if priorStart != nil && bodyRequest.StartDate == nil {
+		cleared = append(cleared, "startDate")
+	}
+	if priorEnd != nil && bodyRequest.EndDate == nil {
+		cleared = append(cleared, "endDate")
+	}
+	if len(cleared) == 0 {
+		return out, nil
+	}
+
+	var payload map[string]map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		return nil, err
+	}
+	attributes, _ := payload["data"]["attributes"].(map[string]interface{})
+	if attributes == nil {
+		attributes = map[string]interface{}{}
+		payload["data"]["attributes"] = attributes
+	}
+	for _, name := range cleared {
+		attributes[name] = nil
+	}
+	out.Reset()
+	if err := json.NewEncoder(out).Encode(payload); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// refreshScheduleWindow builds the window from the API dates. A date that the
+// server echoes in another format but for the same instant keeps its
+// configured spelling.
+func refreshScheduleWindow(current types.Object, startDate *string, endDate *string) types.Object {
+	if (startDate == nil || *startDate == "") && (endDate == nil || *endDate == "") {
+		return types.ObjectNull(scheduleWindowAttrTypes)
+	}
+
+	currentStart, currentEnd := scheduleWindowDates(current)
+	return types.ObjectValueMust(scheduleWindowAttrTypes, map[string]attr.Value{
+		"start": refreshScheduleDate(currentStart, startDate),
+		"end":   refreshScheduleDate(currentEnd, endDate),
+	})
+}
+
+func refreshScheduleDate(current *string, remote *string) types.String {
+	if remote == nil || *remote == "" {
+		return types.StringNull()
+	}
+	if current != nil {
+		currentTime, currentErr := time.Parse(time.RFC3339, *current)
+		remoteTime, remoteErr := time.Parse(time.RFC3339, *remote)
+		if currentErr == nil && remoteErr == nil && currentTime.Equal(remoteTime) {
+			return types.StringValue(*current)
+		}
+	}
+	return types.StringValue(*remote)
+}
+
+func (r *WorkspaceScheduleResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema("workspace_id", "id")
+}
+
 func (r *WorkspaceScheduleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importStateFromIdentity(ctx, req, resp) {
+		return
+	}
+
+	// Schedules have no name, they are found by the name of the template they
+	// run, and don't store the organization.
+	steps := []importStep{organizationImportStep.as(""), workspaceImportStep, {
+		attribute:  "template_id",
+		kind:       "Template",
+		collection: "organization/%[1]s/template",
+		filter:     "template",
+		fields:     []string{"name"},
+		entity:     new(client.OrganizationTemplateEntity),
+	}, {
+		attribute:  "id",
+		kind:       "Schedule",
+		collection: "organization/%[1]s/workspace/%[2]s/schedule",
+		filter:     "schedule",
+		parent:     "templateReference",
+		entity:     new(client.WorkspaceScheduleEntity),
+	}}
+	api := importAPI{client: r.client, endpoint: r.endpoint, token: r.token}
+	if api.importByName(ctx, req, resp, steps...) {
+		return
+	}
+
+	// The organization id of the 'organization_ID,workspace_ID,ID' format
+	// is accepted and ignored.
 	idParts := strings.Split(req.ID, ",")
+	if len(idParts) == 3 {
+		idParts = idParts[1:]
+	}
 
-	if len(idParts) != 3 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" {
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: 'organization_ID,workspace_ID, ID', Got: %q", req.ID),
+			fmt.Sprintf("Expected import identifier with format: 'workspace_ID,ID' or '%s', Got: %q", importNamesFormat(steps...), req.ID),
 		)
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), idParts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("workspace_id"), idParts[1])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("workspace_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[1])...)
 }
@@ -0,0 +1,253 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestQuartzCronValidator(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]bool{
+		"0 0 3 * * ?":        false,
+		"0 0 12 ? * MON-FRI": false,
+		"0 0 3 * * *":        true,
+		"0 0 25 * * ?":       true,
+		"*/5 * * * *":        true,
+	}
+
+	for value, wantError := range tests {
+		resp := &validator.StringResponse{}
+		quartzCronValidator{}.ValidateString(ctx, validator.StringRequest{
+			Path:        path.Root("schedule"),
+			ConfigValue: types.StringValue(value),
+		}, resp)
+
+		if resp.Diagnostics.HasError() != wantError {
+			t.Errorf("schedule %q: got error %t, want %t (%v)", value, resp.Diagnostics.HasError(), wantError, resp.Diagnostics)
+		}
+	}
+}
+
+func TestTimeZoneValidator(t *testing.T) {
+	ctx := context.Background()
+
+	for value, wantError := range map[string]bool{"UTC": false, "Europe/Madrid": false, "Mars/Olympus": true} {
+		resp := &validator.StringResponse{}
+		timeZoneValidator{}.ValidateString(ctx, validator.StringRequest{
+			Path:        path.Root("timezone"),
+			ConfigValue: types.StringValue(value),
+		}, resp)
+
+		if resp.Diagnostics.HasError() != wantError {
+			t.Errorf("timezone %q: got error %t, want %t", value, resp.Diagnostics.HasError(), wantError)
+		}
+	}
+}
+
+func testScheduleModel(schedule string, timezone string, count int64) WorkspaceScheduleResourceModel {
+	return WorkspaceScheduleResourceModel{
+		Schedule:      types.StringValue(schedule),
+		Enabled:       types.BoolValue(true),
+		Timezone:      types.StringValue(timezone),
+		Window:        types.ObjectNull(scheduleWindowAttrTypes),
+		NextRunsCount: types.Int64Value(count),
+	}
+}
+
+func assertScheduleRuns(t *testing.T, runs types.List, want []string) {
+	t.Helper()
+
+	elements := runs.Elements()
+	if len(elements) != len(want) {
+		t.Fatalf("expected %d runs, got %v", len(want), elements)
+	}
+	for i, w := range want {
+		if !elements[i].Equal(types.StringValue(w)) {
+			t.Errorf("run %d = %s, want %s", i, elements[i], w)
+		}
+	}
+}
+
+func TestScheduleNextRuns(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	runs, diags := scheduleNextRuns(testScheduleModel("0 0 3 * * ?", "Europe/Madrid", 2), now)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	assertScheduleRuns(t, runs, []string{"2026-03-02T03:00:00+01:00", "2026-03-03T03:00:00+01:00"})
+
+	runs, diags = scheduleNextRuns(testScheduleModel("0 0 3 * * ?", "UTC", 0), now)
+	if diags.HasError() || runs.IsNull() || len(runs.Elements()) != 0 {
+		t.Errorf("expected an empty, non-null list when no runs are requested, got %v (%v)", runs, diags)
+	}
+}
+
+func TestScheduleNextRuns_DisabledAndWindow(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	disabled := testScheduleModel("0 0 3 * * ?", "UTC", 5)
+	disabled.Enabled = types.BoolValue(false)
+	runs, diags := scheduleNextRuns(disabled, now)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	assertScheduleRuns(t, runs, []string{})
+
+	windowed := testScheduleModel("0 0 3 * * ?", "UTC", 5)
+	windowed.Window = types.ObjectValueMust(scheduleWindowAttrTypes, map[string]attr.Value{
+		"start": types.StringValue("2026-03-10T03:00:00Z"),
+		"end":   types.StringValue("2026-03-12T12:00:00Z"),
+	})
+	runs, diags = scheduleNextRuns(windowed, now)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	assertScheduleRuns(t, runs, []string{"2026-03-10T03:00:00Z", "2026-03-11T03:00:00Z", "2026-03-12T03:00:00Z"})
+}
+
+func TestRefreshScheduleWindow_KeepsEquivalentDates(t *testing.T) {
+	current := types.ObjectValueMust(scheduleWindowAttrTypes, map[string]attr.Value{
+		"start": types.StringValue("2026-12-20T00:00:00Z"),
+		"end":   types.StringNull(),
+	})
+	start := "2026-12-20T01:00:00+01:00"
+
+	got := refreshScheduleWindow(current, &start, nil)
+	if !got.Equal(current) {
+		t.Errorf("expected the configured spelling of the same instant to be kept, got %s", got)
+	}
+
+	moved := "2026-12-21T00:00:00Z"
+	got = refreshScheduleWindow(current, &moved, nil)
+	if got.Attributes()["start"].Equal(types.StringValue("2026-12-20T00:00:00Z")) {
+		t.Errorf("expected a changed start date to be refreshed, got %s", got)
+	}
+
+	if got := refreshScheduleWindow(current, nil, nil); !got.IsNull() {
+		t.Errorf("expected a null window when the server has no dates, got %s", got)
+	}
+}
+
+// TestWorkspaceSchedulePayload_OmitsUnsetAttributes covers servers that
+// predate enabled, timezone and window, which reject them even when null.
+func TestWorkspaceSchedulePayload_OmitsUnsetAttributes(t *testing.T) {
+	plan := testScheduleModel("0 0 3 * * ?", defaultScheduleTimezone, 5)
+	config := WorkspaceScheduleResourceModel{
+		Schedule: plan.Schedule,
+		Enabled:  types.BoolNull(),
+		Timezone: types.StringNull(),
+		Window:   types.ObjectNull(scheduleWindowAttrTypes),
+	}
+
+	out, err := workspaceSchedulePayload(plan, config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, unexpected := range []string{"enabled", "timezone", "startDate", "endDate"} {
+		if strings.Contains(out.String(), `"`+unexpected+`"`) {
+			t.Errorf("expected %s to be omitted, got %s", unexpected, out)
+		}
+	}
+
+	config.Enabled = types.BoolValue(false)
+	plan.Enabled = types.BoolValue(false)
+	out, err = workspaceSchedulePayload(plan, config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.Contains(out.String(), `"enabled":false`) {
+		t.Errorf("expected a configured enabled to be sent, got %s", out)
+	}
+}
+
+func TestWorkspaceSchedulePayload_ClearsRemovedSettings(t *testing.T) {
+	prior := testScheduleModel("0 0 3 * * ?", "Europe/Madrid", 5)
+	prior.ID = types.StringValue("schedule-1")
+	prior.Enabled = types.BoolValue(false)
+	prior.Window = types.ObjectValueMust(scheduleWindowAttrTypes, map[string]attr.Value{
+		"start": types.StringValue("2026-03-10T03:00:00Z"),
+		"end":   types.StringNull(),
+	})
+	plan := testScheduleModel("0 0 3 * * ?", defaultScheduleTimezone, 5)
+	config := WorkspaceScheduleResourceModel{
+		Schedule: plan.Schedule,
+		Enabled:  types.BoolNull(),
+		Timezone: types.StringNull(),
+		Window:   types.ObjectNull(scheduleWindowAttrTypes),
+	}
+
+	out, err := workspaceSchedulePayload(plan, config, &prior)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, want := range []string{`"id":"schedule-1"`, `"enabled":true`, `"timezone":"UTC"`, `"startDate":null`} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected the payload to include %s, got %s", want, out)
+		}
+	}
+	if strings.Contains(out.String(), `"endDate"`) {
+		t.Errorf("expected the end date that was never set to be omitted, got %s", out)
+	}
+}
+
+// TestWorkspaceScheduleResource_Read_KeepsNextRuns covers that refreshing a
+// schedule keeps the fire times of state instead of recomputing them from the
+// clock, which would show a diff on every plan, and only fills them in when
+// state has none, after an import.
+func TestWorkspaceScheduleResource_Read_KeepsNextRuns(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"type":"schedule","id":"sch-1","attributes":{"cron":"0 0 3 * * ?","templateReference":"tpl-1","enabled":true,"timezone":"UTC"}}}`)
+	}))
+	defer server.Close()
+
+	r := &WorkspaceScheduleResource{client: server.Client(), endpoint: server.URL, token: "test-token"}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	listType := objType.AttributeTypes["next_runs"]
+
+	read := func(nextRuns tftypes.Value) types.List {
+		t.Helper()
+		state := buildObjectValue(objType, map[string]tftypes.Value{
+			"id":              tftypes.NewValue(tftypes.String, "sch-1"),
+			"workspace_id":    tftypes.NewValue(tftypes.String, "ws-1"),
+			"next_runs_count": tftypes.NewValue(tftypes.Number, 1),
+			"next_runs":       nextRuns,
+		})
+		resp := &resource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: state}}
+		r.Read(ctx, resource.ReadRequest{State: tfsdk.State{Schema: schemaResp.Schema, Raw: state}}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		var result WorkspaceScheduleResourceModel
+		if diags := resp.State.Get(ctx, &result); diags.HasError() {
+			t.Fatalf("reading resulting state: %v", diags)
+		}
+		return result.NextRuns
+	}
+
+	planned := tftypes.NewValue(listType, []tftypes.Value{tftypes.NewValue(tftypes.String, "2020-01-01T03:00:00Z")})
+	assertScheduleRuns(t, read(planned), []string{"2020-01-01T03:00:00Z"})
+
+	imported := read(tftypes.NewValue(listType, nil))
+	if len(imported.Elements()) != 1 || imported.Elements()[0].Equal(types.StringValue("2020-01-01T03:00:00Z")) {
+		t.Errorf("expected an imported schedule to get its upcoming fire time, got %s", imported)
+	}
+}
@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-terrakube/internal/quartz"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = quartzCronValidator{}
+var _ validator.String = timeZoneValidator{}
+var _ validator.String = rfc3339Validator{}
+
+// quartzCronValidator rejects schedules Terrakube would fail to parse, so
+// typos surface during terraform validate instead of server-side.
+type quartzCronValidator struct{}
+
+func (v quartzCronValidator) Description(ctx context.Context) string {
+	return "value must be a valid Quartz cron expression"
+}
+
+func (v quartzCronValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v quartzCronValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := quartz.Parse(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Quartz Cron Expression",
+			fmt.Sprintf("%q is not a valid Quartz cron expression: %s", req.ConfigValue.ValueString(), err),
+		)
+	}
+}
+
+// timeZoneValidator accepts IANA time zone names such as Europe/Madrid.
+type timeZoneValidator struct{}
+
+func (v timeZoneValidator) Description(ctx context.Context) string {
+	return "value must be an IANA time zone name"
+}
+
+func (v timeZoneValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v timeZoneValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.LoadLocation(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Time Zone",
+			fmt.Sprintf("%q is not a valid IANA time zone name: %s", req.ConfigValue.ValueString(), err),
+		)
+	}
+}
+
+// rfc3339Validator accepts timestamps such as 2026-12-20T00:00:00Z.
+type rfc3339Validator struct{}
+
+func (v rfc3339Validator) Description(ctx context.Context) string {
+	return "value must be a timestamp in RFC 3339 format"
+}
+
+func (v rfc3339Validator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v rfc3339Validator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Timestamp",
+			fmt.Sprintf("%q is not a timestamp in RFC 3339 format, for example 2026-12-20T00:00:00Z: %s", req.ConfigValue.ValueString(), err),
+		)
+	}
+}
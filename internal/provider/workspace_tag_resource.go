@@ -12,6 +12,7 @@ import (
 
 	"github.com/google/jsonapi"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -22,6 +23,7 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &WorkspaceTagResource{}
+var _ resource.ResourceWithIdentity = &WorkspaceTagResource{}
 var _ resource.ResourceWithImportState = &WorkspaceTagResource{}
 
 type WorkspaceTagResource struct {
@@ -162,13 +164,17 @@ func (r *WorkspaceTagResource) Create(ctx context.Context, req resource.CreateRe
 	tflog.Info(ctx, "Workspace Tag Resource Created", map[string]any{"success": true})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *WorkspaceTagResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	tflog.Warn(ctx, "Workspace Tag Resource doesn't have an update action", map[string]any{"success": true})
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *WorkspaceTagResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.Diagnostics.Append(setResourceIdentity(ctx, req.State, resp.Identity)...)
+
 	var state WorkspaceTagResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -249,6 +255,46 @@ func (r *WorkspaceTagResource) Delete(ctx context.Context, req resource.DeleteRe
 	}
 }
 
+func (r *WorkspaceTagResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema("organization_id", "workspace_id", "id")
+}
+
 func (r *WorkspaceTagResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resp.Diagnostics.AddError("Import not implemented", "Import is not implemented for Workspace Tag Resource, please delete and recreate the resource")
+	if importStateFromIdentity(ctx, req, resp) {
+		return
+	}
+
+	steps := []importStep{organizationImportStep, workspaceImportStep, {
+		attribute:  "tag_id",
+		kind:       "Tag",
+		collection: "organization/%[1]s/tag",
+		filter:     "tag",
+		fields:     []string{"name"},
+		entity:     new(client.OrganizationTagEntity),
+	}, {
+		attribute:  "id",
+		kind:       "Workspace tag",
+		collection: "organization/%[1]s/workspace/%[2]s/workspaceTag",
+		filter:     "workspacetag",
+		parent:     "tagId",
+		entity:     new(client.WorkspaceTagEntity),
+	}}
+	api := importAPI{client: r.client, endpoint: r.endpoint, token: r.token}
+	if api.importByName(ctx, req, resp, steps...) {
+		return
+	}
+
+	idParts := strings.Split(req.ID, ",")
+
+	if len(idParts) != 3 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: 'organization_ID,workspace_ID,ID' or '%s', Got: %q", importNamesFormat(steps...), req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("workspace_id"), idParts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[2])...)
 }
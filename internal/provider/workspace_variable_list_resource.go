@@ -0,0 +1,20 @@
+package provider
+
+import (
+	"terraform-provider-terrakube/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+func NewWorkspaceVariableListResource() list.ListResource {
+	return &entityListResource{
+		managed:     NewWorkspaceVariableResource(),
+		description: "List the variables of a workspace.",
+		parents:     []string{"organization_id", "workspace_id"},
+		collection:  "organization/%s/workspace/%s/variable",
+		entity:      new(client.WorkspaceVariableEntity),
+		displayName: func(entity any) string {
+			return entityField(entity, "Key")
+		},
+	}
+}
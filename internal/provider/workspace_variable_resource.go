@@ -11,8 +11,12 @@ import (
 	"terraform-provider-terrakube/internal/client"
 
 	"github.com/google/jsonapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -23,6 +27,7 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &WorkspaceVariableResource{}
+var _ resource.ResourceWithIdentity = &WorkspaceVariableResource{}
 var _ resource.ResourceWithImportState = &WorkspaceVariableResource{}
 
 type WorkspaceVariableResource struct {
@@ -32,15 +37,20 @@ type WorkspaceVariableResource struct {
 }
 
 type WorkspaceVariableResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	OrganizationId types.String `tfsdk:"organization_id"`
-	WorkspaceId    types.String `tfsdk:"workspace_id"`
-	Key            types.String `tfsdk:"key"`
-	Value          types.String `tfsdk:"value"`
-	Description    types.String `tfsdk:"description"`
-	Category       types.String `tfsdk:"category"`
-	Sensitive      types.Bool   `tfsdk:"sensitive"`
-	Hcl            types.Bool   `tfsdk:"hcl"`
+	ID                  types.String `tfsdk:"id"`
+	OrganizationId      types.String `tfsdk:"organization_id"`
+	WorkspaceId         types.String `tfsdk:"workspace_id"`
+	Key                 types.String `tfsdk:"key"`
+	Value               types.String `tfsdk:"value"`
+	Description         types.String `tfsdk:"description"`
+	Category            types.String `tfsdk:"category"`
+	Sensitive           types.Bool   `tfsdk:"sensitive"`
+	Hcl                 types.Bool   `tfsdk:"hcl"`
+	ValueWo             types.String `tfsdk:"value_wo"`
+	ValueWoVersion      types.Int64  `tfsdk:"value_wo_version"`
+	ValueHash           types.String `tfsdk:"value_hash"`
+	ValueVersion        types.String `tfsdk:"value_version"`
+	IgnoreRemoteChanges types.Bool   `tfsdk:"ignore_remote_changes"`
 }
 
 func NewWorkspaceVariableResource() resource.Resource {
@@ -76,8 +86,27 @@ func (r *WorkspaceVariableResource) Schema(ctx context.Context, req resource.Sch
 				Description: "Variable key",
 			},
 			"value": schema.StringAttribute{
-				Required:    true,
-				Description: "Variable value",
+				Optional:    true,
+				Description: "Variable value. Exactly one of `value` or `value_wo` must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("value"), path.MatchRelative().AtParent().AtName("value_wo")),
+				},
+			},
+			"value_wo": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Description: "Write-only variable value, never stored in the Terraform state. Requires Terraform 1.11 or later and `value_wo_version`.",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("value_wo_version")),
+				},
+			},
+			"value_wo_version": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Version of `value_wo`. Change it to send a new `value_wo` to Terrakube.",
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("value_wo")),
+				},
 			},
 			"description": schema.StringAttribute{
 				Required:    true,
@@ -95,6 +124,20 @@ func (r *WorkspaceVariableResource) Schema(ctx context.Context, req resource.Sch
 				Required:    true,
 				Description: "Parse this field as HashiCorp Configuration Language (HCL). This allows you to interpolate values at runtime.",
 			},
+			"value_hash": schema.StringAttribute{
+				Computed:    true,
+				Description: "Salted SHA-256 hash of the value last written by Terraform, used to detect changes made outside of Terraform. Null for sensitive and write-only values, which are never hashed.",
+			},
+			"value_version": schema.StringAttribute{
+				Computed:    true,
+				Description: "Server modification marker recorded when the value was last written. A different marker on refresh means a sensitive or write-only value was changed outside of Terraform. The marker changes with any attribute, so editing only the description outside of Terraform is also planned as a change of the value.",
+			},
+			"ignore_remote_changes": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Keep the last written value when it is changed outside of Terraform instead of planning an update to restore it. Default `false`.",
+			},
 		},
 	}
 }
@@ -141,9 +184,15 @@ func (r *WorkspaceVariableResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
+	value, diags := secretValue(ctx, req.Config, plan.Value, "value_wo")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	bodyRequest := &client.WorkspaceVariableEntity{
 		Key:         plan.Key.ValueString(),
-		Value:       plan.Value.ValueString(),
+		Value:       value.ValueString(),
 		Description: plan.Description.ValueString(),
 		Sensitive:   plan.Sensitive.ValueBool(),
 		Category:    plan.Category.ValueString(),
@@ -187,9 +236,12 @@ func (r *WorkspaceVariableResource) Create(ctx context.Context, req resource.Cre
 
 	tflog.Info(ctx, "Body Response", map[string]any{"bodyResponse": string(bodyResponse)})
 
-	if workspaceVariable.Sensitive {
-		tflog.Info(ctx, "Variable value is not included in response, setting values the same as the plan for sensitive=true...")
-		plan.Value = types.StringValue(plan.Value.ValueString())
+	written := writtenValueSnapshot(types.StringNull(), value, workspaceVariable.Sensitive || plan.Value.IsNull(), workspaceVariable.UpdatedDate)
+	plan.ValueHash = written.Hash
+	plan.ValueVersion = written.Version
+
+	if workspaceVariable.Sensitive || plan.Value.IsNull() {
+		tflog.Info(ctx, "Variable value is not included in response or is write-only, keeping the planned value")
 	} else {
 		tflog.Info(ctx, "Variable value is included in response...")
 		plan.Value = types.StringValue(workspaceVariable.Value)
@@ -205,9 +257,12 @@ func (r *WorkspaceVariableResource) Create(ctx context.Context, req resource.Cre
 	tflog.Info(ctx, "workspace variable Resource Created", map[string]any{"success": true})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *WorkspaceVariableResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.Diagnostics.Append(setResourceIdentity(ctx, req.State, resp.Identity)...)
+
 	var state WorkspaceVariableResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -251,13 +306,12 @@ func (r *WorkspaceVariableResource) Read(ctx context.Context, req resource.ReadR
 
 	tflog.Info(ctx, "Body Response", map[string]any{"bodyResponse": string(bodyResponse)})
 
-	if workspaceVariable.Sensitive {
-		tflog.Info(ctx, "Variable value is not included in response, setting values the same as the current state value")
-		state.Value = types.StringValue(state.Value.ValueString())
-	} else {
-		tflog.Info(ctx, "Variable value is included in response...")
-		state.Value = types.StringValue(workspaceVariable.Value)
-	}
+	refreshed := refreshSensitiveValue(ctx, sensitiveValueSnapshot{Value: state.Value, Hash: state.ValueHash, Version: state.ValueVersion, WriteOnlyVersion: state.ValueWoVersion}, state.IgnoreRemoteChanges.ValueBool(), workspaceVariable.Value, workspaceVariable.Sensitive, workspaceVariable.UpdatedDate)
+	state.Value = refreshed.Value
+	state.ValueHash = refreshed.Hash
+	state.ValueVersion = refreshed.Version
+	state.ValueWoVersion = refreshed.WriteOnlyVersion
+	state.IgnoreRemoteChanges = types.BoolValue(state.IgnoreRemoteChanges.ValueBool())
 
 	state.Key = types.StringValue(workspaceVariable.Key)
 	state.Description = types.StringValue(workspaceVariable.Description)
@@ -286,9 +340,15 @@ func (r *WorkspaceVariableResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
+	value, diags := secretValue(ctx, req.Config, plan.Value, "value_wo")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	bodyRequest := &client.WorkspaceVariableEntity{
 		Key:         plan.Key.ValueString(),
-		Value:       plan.Value.ValueString(),
+		Value:       value.ValueString(),
 		Description: plan.Description.ValueString(),
 		Category:    plan.Category.ValueString(),
 		Sensitive:   plan.Sensitive.ValueBool(),
@@ -354,9 +414,12 @@ func (r *WorkspaceVariableResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
-	if workspaceVariable.Sensitive {
-		tflog.Info(ctx, "Variable value is not included in response, setting values the same as the plan for sensitive=true...")
-		plan.Value = types.StringValue(plan.Value.ValueString())
+	written := writtenValueSnapshot(state.ValueHash, value, workspaceVariable.Sensitive || plan.Value.IsNull(), workspaceVariable.UpdatedDate)
+	plan.ValueHash = written.Hash
+	plan.ValueVersion = written.Version
+
+	if workspaceVariable.Sensitive || plan.Value.IsNull() {
+		tflog.Info(ctx, "Variable value is not included in response or is write-only, keeping the planned value")
 	} else {
 		tflog.Info(ctx, "Variable value is included in response...")
 		plan.Value = types.StringValue(workspaceVariable.Value)
@@ -370,6 +433,7 @@ func (r *WorkspaceVariableResource) Update(ctx context.Context, req resource.Upd
 	plan.Hcl = types.BoolValue(workspaceVariable.Hcl)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *WorkspaceVariableResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -396,13 +460,34 @@ func (r *WorkspaceVariableResource) Delete(ctx context.Context, req resource.Del
 	}
 }
 
+func (r *WorkspaceVariableResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema("organization_id", "workspace_id", "id")
+}
+
 func (r *WorkspaceVariableResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importStateFromIdentity(ctx, req, resp) {
+		return
+	}
+
+	steps := []importStep{organizationImportStep, workspaceImportStep, {
+		attribute:  "id",
+		kind:       "Variable",
+		collection: "organization/%s/workspace/%s/variable",
+		filter:     "variable",
+		fields:     []string{"key"},
+		entity:     new(client.WorkspaceVariableEntity),
+	}}
+	api := importAPI{client: r.client, endpoint: r.endpoint, token: r.token}
+	if api.importByName(ctx, req, resp, steps...) {
+		return
+	}
+
 	idParts := strings.Split(req.ID, ",")
 
 	if len(idParts) != 3 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: 'organization_ID,workspace_ID, ID', Got: %q", req.ID),
+			fmt.Sprintf("Expected import identifier with format: 'organization_ID,workspace_ID, ID' or '%s', Got: %q", importNamesFormat(steps...), req.ID),
 		)
 		return
 	}
@@ -0,0 +1,22 @@
+package provider
+
+import (
+	"terraform-provider-terrakube/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+func NewWorkspaceVcsListResource() list.ListResource {
+	return &entityListResource{
+		managed:     NewWorkspaceVcsResource(),
+		description: "List the VCS driven workspaces of an organization.",
+		parents:     []string{"organization_id"},
+		collection:  "organization/%s/workspace",
+		entity:      new(client.WorkspaceEntity),
+		displayName: entityName,
+		include: func(entity any) bool {
+			workspace := entity.(*client.WorkspaceEntity)
+			return !workspace.Deleted && workspace.Source != "empty"
+		},
+	}
+}
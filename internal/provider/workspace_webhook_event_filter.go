@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ validator.String = webhookEventFilterValidator{}
+
+var javaBackReference = regexp.MustCompile(`\\([1-9]|k<)`)
+
+// re2RepeatCount matches a {n} repeat, filters can't hold the comma of {n,m}.
+var re2RepeatCount = regexp.MustCompile(`^\{\d+\}$`)
+
+// errWebhookEventFilterUnsupported marks Java only constructs, like look
+// arounds, back references and possessive quantifiers, that Terrakube accepts
+// but RE2 cannot evaluate.
+var errWebhookEventFilterUnsupported = errors.New("uses Java regular expression features the provider cannot evaluate")
+
+// compileWebhookEventFilter compiles a filter the way Terrakube matches it.
+// Terrakube stores the branch and path filters of a webhook event joined by
+// commas and matches every filter as a Java regular expression against the
+// whole branch name or changed file.
+func compileWebhookEventFilter(filter string) (*regexp.Regexp, error) {
+	if filter == "" {
+		return nil, errors.New("filters cannot be empty")
+	}
+	if strings.Contains(filter, ",") {
+		return nil, errors.New("filters cannot contain commas, Terrakube stores them as a comma separated list")
+	}
+
+	compiled, err := regexp.Compile("^(?:" + filter + ")$")
+	if err == nil {
+		return compiled, nil
+	}
+
+	var syntaxErr *syntax.Error
+	if errors.As(err, &syntaxErr) {
+		if syntaxErr.Code == syntax.ErrInvalidPerlOp || javaBackReference.MatchString(filter) || re2OnlySyntaxError(syntaxErr) {
+			return nil, errWebhookEventFilterUnsupported
+		}
+		if syntaxErr.Code == syntax.ErrMissingRepeatArgument {
+			return nil, fmt.Errorf("%s, filters are regular expressions rather than globs, for example .*\\.tf instead of *.tf", syntaxErr.Code)
+		}
+		return nil, errors.New(syntaxErr.Code.String())
+	}
+	return nil, err
+}
+
+// re2OnlySyntaxError reports whether RE2 rejects valid Java syntax: a
+// possessive quantifier like .*+, or a repeat over RE2's limit of 1000.
+func re2OnlySyntaxError(err *syntax.Error) bool {
+	switch err.Code {
+	case syntax.ErrInvalidRepeatOp:
+		return strings.HasSuffix(err.Expr, "+")
+	case syntax.ErrInvalidRepeatSize:
+		return re2RepeatCount.MatchString(err.Expr)
+	}
+	return false
+}
+
+// webhookEventFilterMatches returns the values matched by at least one filter.
+func webhookEventFilterMatches(filters, values []string) ([]string, error) {
+	compiled := make([]*regexp.Regexp, 0, len(filters))
+	for _, filter := range filters {
+		expression, err := compileWebhookEventFilter(filter)
+		if err != nil {
+			return nil, fmt.Errorf("filter %q: %w", filter, err)
+		}
+		compiled = append(compiled, expression)
+	}
+
+	matches := []string{}
+	for _, value := range values {
+		for _, expression := range compiled {
+			if expression.MatchString(value) {
+				matches = append(matches, value)
+				break
+			}
+		}
+	}
+	return matches, nil
+}
+
+// webhookEventFilterEvents lists the event types each filter other than
+// branch and path applies to.
+var webhookEventFilterEvents = []struct {
+	name  string
+	event string
+}{
+	{name: "tag", event: "RELEASE"},
+	{name: "source_branch", event: "PULL_REQUEST"},
+	{name: "label", event: "PULL_REQUEST"},
+}
+
+// validateWebhookEventFilters rejects tag filters on events other than
+// RELEASE, and source branch and label filters on events other than
+// PULL_REQUEST, since Terrakube would never match them.
+func validateWebhookEventFilters(event types.String, filters map[string]types.List, attributePath func(name string) path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if event.IsUnknown() {
+		return diags
+	}
+	eventType := event.ValueString()
+	if event.IsNull() {
+		eventType = "PUSH"
+	}
+
+	for _, filter := range webhookEventFilterEvents {
+		if value := filters[filter.name]; value.IsNull() || eventType == filter.event {
+			continue
+		}
+		diags.AddAttributeError(
+			attributePath(filter.name),
+			"Invalid Webhook Event Filter",
+			fmt.Sprintf("%s filters only apply to %s events, this event is %s.", filter.name, filter.event, eventType),
+		)
+	}
+	return diags
+}
+
+// webhookEventFilterValues joins the tag, source branch and label filters
+// the way Terrakube stores them, keyed by their API attribute names.
+func webhookEventFilterValues(ctx context.Context, tag, sourceBranch, label types.List) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	values := map[string]string{}
+	for name, list := range map[string]types.List{"tag": tag, "sourceBranch": sourceBranch, "label": label} {
+		var filters []string
+		if !list.IsNull() && !list.IsUnknown() {
+			diags.Append(list.ElementsAs(ctx, &filters, false)...)
+		}
+		values[name] = strings.Join(filters, ",")
+	}
+	return values, diags
+}
+
+// setWebhookEventFilterAttributes adds the tag, source branch and label
+// filters to the attributes of an atomic operation. Terrakube releases
+// without these filters reject unknown attributes, so a filter is only sent
+// when it is set, or was set before and has to be cleared.
+func setWebhookEventFilterAttributes(attributes map[string]interface{}, filters, prior map[string]string) {
+	for name, value := range filters {
+		if value != "" || prior[name] != "" {
+			attributes[name] = value
+		}
+	}
+}
+
+// webhookEventFilterValidator rejects branch and path filters Terrakube would
+// fail to match, so typos surface during terraform validate instead of when a
+// push is ignored.
+type webhookEventFilterValidator struct{}
+
+func (v webhookEventFilterValidator) Description(ctx context.Context) string {
+	return "value must be a regular expression without commas"
+}
+
+func (v webhookEventFilterValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v webhookEventFilterValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	_, err := compileWebhookEventFilter(req.ConfigValue.ValueString())
+	if err != nil && !errors.Is(err, errWebhookEventFilterUnsupported) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Webhook Event Filter",
+			fmt.Sprintf("%q is not a valid webhook event filter: %s", req.ConfigValue.ValueString(), err),
+		)
+	}
+}
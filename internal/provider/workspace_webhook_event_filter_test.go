@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestWebhookEventFilterValidator(t *testing.T) {
+	tests := []struct {
+		filter string
+		valid  bool
+	}{
+		{filter: "main", valid: true},
+		{filter: "release/.*", valid: true},
+		{filter: "modules/.*\\.tf", valid: true},
+		{filter: "(?!main).*", valid: true},
+		{filter: "(a)\\1", valid: true},
+		{filter: "release/.*+", valid: true},
+		{filter: "v[0-9]{1001}", valid: true},
+		{filter: "release/.**"},
+		{filter: "*.tf"},
+		{filter: "feature/[a-z"},
+		{filter: "v[0-9]{1,3}"},
+		{filter: ""},
+	}
+
+	for _, test := range tests {
+		resp := &validator.StringResponse{}
+		webhookEventFilterValidator{}.ValidateString(context.Background(), validator.StringRequest{
+			Path:        path.Root("branch").AtListIndex(0),
+			ConfigValue: types.StringValue(test.filter),
+		}, resp)
+		if resp.Diagnostics.HasError() == test.valid {
+			t.Errorf("filter %q: expected valid=%t, got %v", test.filter, test.valid, resp.Diagnostics)
+		}
+	}
+}
+
+func TestCompileWebhookEventFilter_RE2OnlySyntax(t *testing.T) {
+	for _, filter := range []string{"feature/.*+", "v[0-9]++", "main?+", "x{2}+", "[a-z]{1001}"} {
+		if _, err := compileWebhookEventFilter(filter); !errors.Is(err, errWebhookEventFilterUnsupported) {
+			t.Errorf("filter %q: expected it to be unsupported, got %v", filter, err)
+		}
+	}
+	for _, filter := range []string{"feature/.**", "a+*", "x{3}{4}{5}"} {
+		if _, err := compileWebhookEventFilter(filter); err == nil || errors.Is(err, errWebhookEventFilterUnsupported) {
+			t.Errorf("filter %q: expected it to be invalid, got %v", filter, err)
+		}
+	}
+}
+
+func TestWebhookEventFilterMatches_WholeValue(t *testing.T) {
+	matches, err := webhookEventFilterMatches([]string{"main", "release/.*"}, []string{"main", "maintenance", "release/1.0", "hotfix/main"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(matches) != 2 || matches[0] != "main" || matches[1] != "release/1.0" {
+		t.Errorf("unexpected matches %v", matches)
+	}
+}
+
+func TestSetWebhookEventFilterAttributes(t *testing.T) {
+	attributes := map[string]interface{}{}
+	setWebhookEventFilterAttributes(attributes,
+		map[string]string{"tag": "v.*", "sourceBranch": "", "label": ""},
+		map[string]string{"tag": "", "sourceBranch": "feature/.*", "label": ""},
+	)
+
+	if attributes["tag"] != "v.*" {
+		t.Errorf("expected the tag filter to be sent, got %v", attributes)
+	}
+	if value, ok := attributes["sourceBranch"]; !ok || value != "" {
+		t.Errorf("expected the removed source branch filter to be cleared, got %v", attributes)
+	}
+	if _, ok := attributes["label"]; ok {
+		t.Errorf("expected the label filter that was never set to be omitted, got %v", attributes)
+	}
+}
@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const webhookEventPriorityConflictSummary = "Conflicting Webhook Event Priority"
+
+// webhookEventPriorityConflict returns the id of another event of the webhook,
+// included in the webhook response, that already has the priority.
+func webhookEventPriorityConflict(webhook webhookAPIResponse, eventID string, priority int64) (string, bool) {
+	for _, included := range webhook.Included {
+		if included.Type == "webhook_event" && included.ID != eventID && int64(included.Attributes.Priority) == priority {
+			return included.ID, true
+		}
+	}
+	return "", false
+}
+
+// webhookEventPriorityRejected reports whether the API rejected an event
+// because another event of the webhook has the same priority.
+func webhookEventPriorityRejected(statusCode int, detail string) bool {
+	detail = strings.ToLower(detail)
+	if !strings.Contains(detail, "priority") {
+		return false
+	}
+	if statusCode == http.StatusConflict {
+		return true
+	}
+	for _, reason := range []string{"unique", "duplicate", "already exists", "constraint"} {
+		if strings.Contains(detail, reason) {
+			return true
+		}
+	}
+	return false
+}
+
+func webhookEventPriorityConflictDetail(webhookID string, priority int64, cause string) string {
+	return fmt.Sprintf("Webhook %s already has an event with priority %d: %s. Priorities must be unique within a webhook, "+
+		"set a different priority on one of the events.", webhookID, priority, cause)
+}
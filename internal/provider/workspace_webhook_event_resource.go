@@ -9,9 +9,12 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"terraform-provider-terrakube/internal/client"
 
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -26,7 +29,9 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &WorkspaceWebhookEventResource{}
+var _ resource.ResourceWithIdentity = &WorkspaceWebhookEventResource{}
 var _ resource.ResourceWithImportState = &WorkspaceWebhookEventResource{}
+var _ resource.ResourceWithValidateConfig = &WorkspaceWebhookEventResource{}
 
 type WorkspaceWebhookEventResource struct {
 	client   *http.Client
@@ -40,6 +45,9 @@ type WorkspaceWebhookEventResourceModel struct {
 	Event             types.String `tfsdk:"event"`
 	Branch            types.List   `tfsdk:"branch"`
 	Path              types.List   `tfsdk:"path"`
+	Tag               types.List   `tfsdk:"tag"`
+	SourceBranch      types.List   `tfsdk:"source_branch"`
+	Label             types.List   `tfsdk:"label"`
 	Priority          types.Int64  `tfsdk:"priority"`
 	TemplateId        types.String `tfsdk:"template_id"`
 	PrWorkflowEnabled types.Bool   `tfsdk:"pr_workflow_enabled"`
@@ -53,6 +61,9 @@ type webhookEventAPIResponse struct {
 		Attributes struct {
 			Branch            string `json:"branch"`
 			Path              string `json:"path"`
+			Tag               string `json:"tag"`
+			SourceBranch      string `json:"sourceBranch"`
+			Label             string `json:"label"`
 			TemplateId        string `json:"templateId"`
 			Event             string `json:"event"`
 			Priority          int    `json:"priority"`
@@ -102,6 +113,14 @@ type webhookAPIResponse struct {
 			} `json:"events"`
 		} `json:"relationships"`
 	} `json:"data"`
+	// Included holds the events when the webhook is read with include=events.
+	Included []struct {
+		Type       string `json:"type"`
+		ID         string `json:"id"`
+		Attributes struct {
+			Priority int `json:"priority"`
+		} `json:"attributes"`
+	} `json:"included"`
 }
 
 func NewWorkspaceWebhookEventResource() resource.Resource {
@@ -135,15 +154,45 @@ func (r *WorkspaceWebhookEventResource) Schema(ctx context.Context, req resource
 				Optional:    true,
 				Description: "The file paths in regex that trigger a run.",
 				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(webhookEventFilterValidator{}),
+				},
 			},
 			"branch": schema.ListAttribute{
 				Optional:    true,
-				Description: "A list of branches that trigger a run. Support regex for more complex matching.",
+				Description: "A list of branches that trigger a run, the target branch for `PULL_REQUEST` events. Support regex for more complex matching.",
 				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(webhookEventFilterValidator{}),
+				},
+			},
+			"tag": schema.ListAttribute{
+				Optional:    true,
+				Description: "The tags in regex that trigger a run, for example `v[0-9]+\\.[0-9]+\\.[0-9]+` for semver tags (`RELEASE` events only). Requires a Terrakube release supporting tag filters; older instances will ignore or reject it.",
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(webhookEventFilterValidator{}),
+				},
+			},
+			"source_branch": schema.ListAttribute{
+				Optional:    true,
+				Description: "The source branches in regex of the pull requests that trigger a run (`PULL_REQUEST` events only). Requires a Terrakube release supporting source branch filters; older instances will ignore or reject it.",
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(webhookEventFilterValidator{}),
+				},
+			},
+			"label": schema.ListAttribute{
+				Optional:    true,
+				Description: "The labels in regex that trigger a run, a pull request triggers it when one of its labels matches (`PULL_REQUEST` events only). Requires a Terrakube release supporting label filters; older instances will ignore or reject it.",
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(webhookEventFilterValidator{}),
+				},
 			},
 			"priority": schema.Int64Attribute{
 				Optional:    true,
-				Description: "The priority of this webhook event",
+				Description: "The priority of this webhook event, unique within the webhook.",
 				Computed:    true,
 			},
 			"template_id": schema.StringAttribute{
@@ -175,6 +224,20 @@ func (r *WorkspaceWebhookEventResource) Schema(ctx context.Context, req resource
 	}
 }
 
+func (r *WorkspaceWebhookEventResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config WorkspaceWebhookEventResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateWebhookEventFilters(config.Event, map[string]types.List{
+		"tag":           config.Tag,
+		"source_branch": config.SourceBranch,
+		"label":         config.Label,
+	}, path.Root)...)
+}
+
 func (r *WorkspaceWebhookEventResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -216,8 +279,12 @@ func (r *WorkspaceWebhookEventResource) Create(ctx context.Context, req resource
 		return
 	}
 
+	// Other events of the webhook may be applied in parallel, the webhook is
+	// locked from reading it until the event is added.
+	defer lockParent(ctx, "webhook", plan.WebhookId.ValueString())()
+
 	// First, get the webhook details to get organization and workspace IDs
-	webhookRequest, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v1/webhook/%s", r.endpoint, plan.WebhookId.ValueString()), nil)
+	webhookRequest, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v1/webhook/%s?include=events", r.endpoint, plan.WebhookId.ValueString()), nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating webhook read request", fmt.Sprintf("Error creating webhook read request: %s", err))
 		return
@@ -254,6 +321,14 @@ func (r *WorkspaceWebhookEventResource) Create(ctx context.Context, req resource
 		return
 	}
 
+	if !plan.Priority.IsUnknown() && !plan.Priority.IsNull() {
+		if conflict, ok := webhookEventPriorityConflict(webhookResp, "", plan.Priority.ValueInt64()); ok {
+			resp.Diagnostics.AddAttributeError(path.Root("priority"), webhookEventPriorityConflictSummary,
+				webhookEventPriorityConflictDetail(plan.WebhookId.ValueString(), plan.Priority.ValueInt64(), fmt.Sprintf("event %s", conflict)))
+			return
+		}
+	}
+
 	eventID := uuid.New().String()
 	tflog.Debug(ctx, "Creating webhook event request", map[string]any{
 		"id": eventID,
@@ -266,28 +341,32 @@ func (r *WorkspaceWebhookEventResource) Create(ctx context.Context, req resource
 	if !plan.Path.IsNull() && !plan.Path.IsUnknown() {
 		resp.Diagnostics.Append(plan.Path.ElementsAs(ctx, &pathList, false)...)
 	}
+	filters, diags := webhookEventFilterValues(ctx, plan.Tag, plan.SourceBranch, plan.Label)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	priority := plan.Priority.ValueInt64()
+	attributes := map[string]interface{}{
+		"priority":          priority,
+		"event":             plan.Event.ValueString(),
+		"branch":            strings.Join(branchList, ","),
+		"path":              strings.Join(pathList, ","),
+		"templateId":        plan.TemplateId.ValueString(),
+		"prWorkflowEnabled": plan.PrWorkflowEnabled.ValueBool(),
+		"prApplyEnabled":    plan.PrApplyEnabled.ValueBool(),
+	}
+	setWebhookEventFilterAttributes(attributes, filters, nil)
 	atomicOperation := map[string]interface{}{
 		"atomic:operations": []map[string]interface{}{
 			{
 				"op":   "add",
 				"href": fmt.Sprintf("/webhook/%s/events", plan.WebhookId.ValueString()),
 				"data": map[string]interface{}{
-					"type": "webhook_event",
-					"id":   eventID,
-					"attributes": map[string]interface{}{
-						"priority":          priority,
-						"event":             plan.Event.ValueString(),
-						"branch":            strings.Join(branchList, ","),
-						"path":              strings.Join(pathList, ","),
-						"templateId":        plan.TemplateId.ValueString(),
-						"prWorkflowEnabled": plan.PrWorkflowEnabled.ValueBool(),
-						"prApplyEnabled":    plan.PrApplyEnabled.ValueBool(),
-					},
+					"type":       "webhook_event",
+					"id":         eventID,
+					"attributes": attributes,
 				},
 			},
 		},
@@ -357,6 +436,11 @@ func (r *WorkspaceWebhookEventResource) Create(ctx context.Context, req resource
 			"status_code": response.StatusCode,
 			"body":        string(bodyResponse),
 		})
+		if webhookEventPriorityRejected(response.StatusCode, decodedDetail) {
+			resp.Diagnostics.AddAttributeError(path.Root("priority"), webhookEventPriorityConflictSummary,
+				webhookEventPriorityConflictDetail(plan.WebhookId.ValueString(), plan.Priority.ValueInt64(), decodedDetail))
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Failed to create/update webhook event",
 			decodedDetail,
@@ -397,6 +481,7 @@ func (r *WorkspaceWebhookEventResource) Create(ctx context.Context, req resource
 	tflog.Info(ctx, "Workspace Webhook Event Resource Created", map[string]any{"success": true})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *WorkspaceWebhookEventResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -408,6 +493,8 @@ func (r *WorkspaceWebhookEventResource) Delete(ctx context.Context, req resource
 		return
 	}
 
+	defer lockParent(ctx, "webhook", data.WebhookId.ValueString())()
+
 	// First, get the webhook details to get organization and workspace IDs
 	webhookRequest, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v1/webhook/%s", r.endpoint, data.WebhookId.ValueString()), nil)
 	if err != nil {
@@ -549,6 +636,8 @@ func (r *WorkspaceWebhookEventResource) Delete(ctx context.Context, req resource
 }
 
 func (r *WorkspaceWebhookEventResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.Diagnostics.Append(setResourceIdentity(ctx, req.State, resp.Identity)...)
+
 	var state WorkspaceWebhookEventResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -599,6 +688,9 @@ func (r *WorkspaceWebhookEventResource) Read(ctx context.Context, req resource.R
 			Attributes struct {
 				Branch            string `json:"branch"`
 				Path              string `json:"path"`
+				Tag               string `json:"tag"`
+				SourceBranch      string `json:"sourceBranch"`
+				Label             string `json:"label"`
 				TemplateId        string `json:"templateId"`
 				Event             string `json:"event"`
 				Priority          int    `json:"priority"`
@@ -637,6 +729,8 @@ func (r *WorkspaceWebhookEventResource) Read(ctx context.Context, req resource.R
 	resp.Diagnostics.Append(pathDiags...)
 	state.Path = pathList
 
+	resp.Diagnostics.Append(setWebhookEventFilters(ctx, &state, eventResp.Data.Attributes.Tag, eventResp.Data.Attributes.SourceBranch, eventResp.Data.Attributes.Label)...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -664,7 +758,9 @@ func (r *WorkspaceWebhookEventResource) Update(ctx context.Context, req resource
 	// forced recreation), webhook_id changes and state still holds the old,
 	// now-deleted webhook's ID. Looking it up by the old ID here would 404
 	// even though the update itself is perfectly valid against the new one.
-	webhookRequest, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v1/webhook/%s", r.endpoint, plan.WebhookId.ValueString()), nil)
+	defer lockParent(ctx, "webhook", plan.WebhookId.ValueString())()
+
+	webhookRequest, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v1/webhook/%s?include=events", r.endpoint, plan.WebhookId.ValueString()), nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating webhook read request", fmt.Sprintf("Error creating webhook read request: %s", err))
 		return
@@ -696,6 +792,14 @@ func (r *WorkspaceWebhookEventResource) Update(ctx context.Context, req resource
 		return
 	}
 
+	if !plan.Priority.IsUnknown() && !plan.Priority.IsNull() {
+		if conflict, ok := webhookEventPriorityConflict(webhookDetails, state.ID.ValueString(), plan.Priority.ValueInt64()); ok {
+			resp.Diagnostics.AddAttributeError(path.Root("priority"), webhookEventPriorityConflictSummary,
+				webhookEventPriorityConflictDetail(plan.WebhookId.ValueString(), plan.Priority.ValueInt64(), fmt.Sprintf("event %s", conflict)))
+			return
+		}
+	}
+
 	tflog.Debug(ctx, "Parsed webhook details", map[string]any{
 		"organization_id": webhookDetails.Data.Relationships.Organization.Data.ID,
 		"workspace_id":    webhookDetails.Data.Relationships.Workspace.Data.ID,
@@ -781,10 +885,24 @@ func (r *WorkspaceWebhookEventResource) Update(ctx context.Context, req resource
 	if !plan.Path.IsNull() && !plan.Path.IsUnknown() {
 		resp.Diagnostics.Append(plan.Path.ElementsAs(ctx, &pathList, false)...)
 	}
+	filters, diags := webhookEventFilterValues(ctx, plan.Tag, plan.SourceBranch, plan.Label)
+	resp.Diagnostics.Append(diags...)
+	priorFilters, diags := webhookEventFilterValues(ctx, state.Tag, state.SourceBranch, state.Label)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	attributes := map[string]interface{}{
+		"priority":          plan.Priority.ValueInt64(),
+		"event":             plan.Event.ValueString(),
+		"branch":            strings.Join(branchList, ","),
+		"path":              strings.Join(pathList, ","),
+		"templateId":        plan.TemplateId.ValueString(),
+		"prWorkflowEnabled": plan.PrWorkflowEnabled.ValueBool(),
+		"prApplyEnabled":    plan.PrApplyEnabled.ValueBool(),
+	}
+	setWebhookEventFilterAttributes(attributes, filters, priorFilters)
 	atomicOperation := map[string]interface{}{
 		"atomic:operations": []map[string]interface{}{
 			{
@@ -795,17 +913,9 @@ func (r *WorkspaceWebhookEventResource) Update(ctx context.Context, req resource
 					plan.WebhookId.ValueString(),
 					state.ID.ValueString()),
 				"data": map[string]interface{}{
-					"type": "webhook_event",
-					"id":   state.ID.ValueString(),
-					"attributes": map[string]interface{}{
-						"priority":          plan.Priority.ValueInt64(),
-						"event":             plan.Event.ValueString(),
-						"branch":            strings.Join(branchList, ","),
-						"path":              strings.Join(pathList, ","),
-						"templateId":        plan.TemplateId.ValueString(),
-						"prWorkflowEnabled": plan.PrWorkflowEnabled.ValueBool(),
-						"prApplyEnabled":    plan.PrApplyEnabled.ValueBool(),
-					},
+					"type":       "webhook_event",
+					"id":         state.ID.ValueString(),
+					"attributes": attributes,
 				},
 			},
 		},
@@ -875,6 +985,11 @@ func (r *WorkspaceWebhookEventResource) Update(ctx context.Context, req resource
 			"status_code": response.StatusCode,
 			"body":        string(bodyResponse),
 		})
+		if webhookEventPriorityRejected(response.StatusCode, decodedDetail) {
+			resp.Diagnostics.AddAttributeError(path.Root("priority"), webhookEventPriorityConflictSummary,
+				webhookEventPriorityConflictDetail(plan.WebhookId.ValueString(), plan.Priority.ValueInt64(), decodedDetail))
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Failed to create/update webhook event",
 			decodedDetail,
@@ -947,6 +1062,9 @@ func (r *WorkspaceWebhookEventResource) Update(ctx context.Context, req resource
 		Attributes struct {
 			Branch            string `json:"branch"`
 			Path              string `json:"path"`
+			Tag               string `json:"tag"`
+			SourceBranch      string `json:"sourceBranch"`
+			Label             string `json:"label"`
 			TemplateId        string `json:"templateId"`
 			Event             string `json:"event"`
 			Priority          int    `json:"priority"`
@@ -982,6 +1100,7 @@ func (r *WorkspaceWebhookEventResource) Update(ctx context.Context, req resource
 	plan.ID = types.StringValue(state.ID.ValueString())
 	plan.Path, _ = types.ListValueFrom(ctx, types.StringType, strings.Split(foundEvent.Attributes.Path, ","))
 	plan.Branch, _ = types.ListValueFrom(ctx, types.StringType, strings.Split(foundEvent.Attributes.Branch, ","))
+	resp.Diagnostics.Append(setWebhookEventFilters(ctx, &plan, foundEvent.Attributes.Tag, foundEvent.Attributes.SourceBranch, foundEvent.Attributes.Label)...)
 	plan.TemplateId = types.StringValue(foundEvent.Attributes.TemplateId)
 	plan.Event = types.StringValue(foundEvent.Attributes.Event)
 	plan.Priority = types.Int64Value(int64(foundEvent.Attributes.Priority))
@@ -989,15 +1108,53 @@ func (r *WorkspaceWebhookEventResource) Update(ctx context.Context, req resource
 	plan.PrApplyEnabled = types.BoolValue(foundEvent.Attributes.PrApplyEnabled)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
+}
+
+func (r *WorkspaceWebhookEventResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema("webhook_id", "id")
+}
+
+// webhookEventImportEntity is the part of a webhook event read to import it
+// by name, client.WorkspaceWebhookEventEntity doesn't unmarshal the webhook
+// relationship returned with the events of a webhook.
+type webhookEventImportEntity struct {
+	ID       string `jsonapi:"primary,webhook_event"`
+	Priority int32  `jsonapi:"attr,priority"`
 }
 
 func (r *WorkspaceWebhookEventResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importStateFromIdentity(ctx, req, resp) {
+		return
+	}
+
+	// Events are found by their priority, unique within the webhook of the
+	// workspace.
+	steps := []importStep{organizationImportStep.as(""), workspaceImportStep.as(""), {
+		attribute:  "webhook_id",
+		kind:       "Webhook",
+		collection: "organization/%[1]s/workspace/%[2]s/webhook",
+		filter:     "webhook",
+		entity:     new(client.WorkspaceWebhookEntity),
+	}, {
+		attribute:  "id",
+		kind:       "Webhook event",
+		collection: "organization/%[1]s/workspace/%[2]s/webhook/%[3]s/events",
+		filter:     "webhook_event",
+		fields:     []string{"priority"},
+		entity:     new(webhookEventImportEntity),
+	}}
+	api := importAPI{client: r.client, endpoint: r.endpoint, token: r.token}
+	if api.importByName(ctx, req, resp, steps...) {
+		return
+	}
+
 	idParts := strings.Split(req.ID, ",")
 
 	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: 'webhook_ID,event_ID', Got: %q", req.ID),
+			fmt.Sprintf("Expected import identifier with format: 'webhook_ID,event_ID' or '%s', Got: %q", importNamesFormat(steps...), req.ID),
 		)
 		return
 	}
@@ -1009,3 +1166,16 @@ func (r *WorkspaceWebhookEventResource) ImportState(ctx context.Context, req res
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, webhookIdPath, idParts[0])...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, idPath, idParts[1])...)
 }
+
+// setWebhookEventFilters sets the tag, source branch and label filters read
+// from Terrakube, null when empty like the unset attributes.
+func setWebhookEventFilters(ctx context.Context, model *WorkspaceWebhookEventResourceModel, tag, sourceBranch, label string) diag.Diagnostics {
+	var diags, listDiags diag.Diagnostics
+	model.Tag, listDiags = webhookV2EventList(ctx, tag)
+	diags.Append(listDiags...)
+	model.SourceBranch, listDiags = webhookV2EventList(ctx, sourceBranch)
+	diags.Append(listDiags...)
+	model.Label, listDiags = webhookV2EventList(ctx, label)
+	diags.Append(listDiags...)
+	return diags
+}
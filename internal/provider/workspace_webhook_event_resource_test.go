@@ -576,3 +576,285 @@ func TestWorkspaceWebhookEventResource_Update_UsesPlanWebhookIdNotStaleState(t *
 		t.Errorf("expected webhook_id in state to be the new webhook %q, got %q", newWebhookID, result.WebhookId.ValueString())
 	}
 }
+
+func TestWorkspaceWebhookEventResource_Create_RejectsConflictingPriority(t *testing.T) {
+	ctx := context.Background()
+	s, objType := webhookEventSchemaAndType(t, ctx)
+
+	const webhookID = "wh-conflict-1"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/webhook/"+webhookID, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("include") != "events" {
+			t.Errorf("expected the webhook to be read with its events, got query %q", req.URL.RawQuery)
+		}
+		fmt.Fprintf(w, `{"data":{"type":"webhook","id":%q,"relationships":{"events":{"data":[{"type":"webhook_event","id":"event-1"}]}}},`+
+			`"included":[{"type":"webhook_event","id":"event-1","attributes":{"priority":1}}]}`, webhookID)
+	})
+	mux.HandleFunc("/api/v1/operations", func(w http.ResponseWriter, _ *http.Request) {
+		t.Error("expected no atomic operation for a conflicting priority")
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	r := &WorkspaceWebhookEventResource{client: server.Client(), endpoint: server.URL, token: "test-token"}
+
+	planValue := buildObjectValue(objType, map[string]tftypes.Value{
+		"webhook_id": tftypes.NewValue(tftypes.String, webhookID),
+		"event":      tftypes.NewValue(tftypes.String, "PUSH"),
+		"priority":   tftypes.NewValue(tftypes.Number, big.NewFloat(1)),
+	})
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: s}}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: planValue}}, resp)
+
+	if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != webhookEventPriorityConflictSummary {
+		t.Fatalf("expected a priority conflict, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestWorkspaceWebhookEventResource_Update_SkipsConflictCheckForUnknownPriority(t *testing.T) {
+	ctx := context.Background()
+	s, objType := webhookEventSchemaAndType(t, ctx)
+
+	const (
+		webhookID   = "wh-unknown-1"
+		workspaceID = "ws-unknown-1"
+		orgID       = "org-unknown-1"
+		eventID     = "event-unknown-1"
+	)
+
+	var operations int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/webhook/"+webhookID, func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `{"data":{"type":"webhook","id":%q,"relationships":{"organization":{"data":{"type":"organization","id":%q}},`+
+			`"workspace":{"data":{"type":"workspace","id":%q}},"events":{"data":[{"type":"webhook_event","id":"event-other"}]}}},`+
+			`"included":[{"type":"webhook_event","id":"event-other","attributes":{"priority":0}}]}`, webhookID, orgID, workspaceID)
+	})
+	mux.HandleFunc("/api/v1/workspace/"+workspaceID, func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `{"data":{"type":"workspace","id":%q,"attributes":{"name":"ws"},`+
+			`"relationships":{"organization":{"data":{"type":"organization","id":%q}}}}}`, workspaceID, orgID)
+	})
+	mux.HandleFunc("/api/v1/operations", func(w http.ResponseWriter, _ *http.Request) {
+		operations++
+		fmt.Fprintf(w, `{"atomic:results":[{"data":{"type":"webhook_event","id":%q}}]}`, eventID)
+	})
+	mux.HandleFunc(fmt.Sprintf("/api/v1/organization/%s/workspace/%s/webhook/%s/events", orgID, workspaceID, webhookID), func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `{"data":[{"type":"webhook_event","id":%q,"attributes":{"event":"PUSH","priority":2,`+
+			`"createdBy":"x","createdDate":"x","updatedBy":"x","updatedDate":"x"},`+
+			`"relationships":{"webhook":{"data":{"type":"webhook","id":%q}}}}]}`, eventID, webhookID)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	r := &WorkspaceWebhookEventResource{client: server.Client(), endpoint: server.URL, token: "test-token"}
+
+	state := map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, eventID),
+		"webhook_id": tftypes.NewValue(tftypes.String, webhookID),
+		"event":      tftypes.NewValue(tftypes.String, "PUSH"),
+		"priority":   tftypes.NewValue(tftypes.Number, big.NewFloat(2)),
+	}
+	plan := map[string]tftypes.Value{}
+	for k, v := range state {
+		plan[k] = v
+	}
+	plan["priority"] = tftypes.NewValue(tftypes.Number, tftypes.UnknownValue)
+
+	req := resource.UpdateRequest{
+		State: tfsdk.State{Schema: s, Raw: buildObjectValue(objType, state)},
+		Plan:  tfsdk.Plan{Schema: s, Raw: buildObjectValue(objType, plan)},
+	}
+	resp := &resource.UpdateResponse{State: tfsdk.State{Schema: s}}
+	r.Update(ctx, req, resp)
+
+	for _, d := range resp.Diagnostics {
+		if d.Summary() == webhookEventPriorityConflictSummary {
+			t.Fatalf("expected no priority conflict for an unknown priority, got: %v", resp.Diagnostics)
+		}
+	}
+	if operations != 1 {
+		t.Errorf("expected the update to be sent, got %d atomic operations", operations)
+	}
+}
+
+func TestWorkspaceWebhookEventResource_Create_ReportsRejectedPriority(t *testing.T) {
+	ctx := context.Background()
+	s, objType := webhookEventSchemaAndType(t, ctx)
+
+	const webhookID = "wh-conflict-2"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/webhook/"+webhookID, func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `{"data":{"type":"webhook","id":%q}}`, webhookID)
+	})
+	mux.HandleFunc("/api/v1/operations", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"errors":[{"detail":"could not execute statement; constraint [uk_webhook_event_priority]; Duplicate entry for webhook and priority"}]}`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	r := &WorkspaceWebhookEventResource{client: server.Client(), endpoint: server.URL, token: "test-token"}
+
+	planValue := buildObjectValue(objType, map[string]tftypes.Value{
+		"webhook_id": tftypes.NewValue(tftypes.String, webhookID),
+		"event":      tftypes.NewValue(tftypes.String, "PUSH"),
+		"priority":   tftypes.NewValue(tftypes.Number, big.NewFloat(2)),
+	})
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: s}}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: planValue}}, resp)
+
+	if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != webhookEventPriorityConflictSummary {
+		t.Fatalf("expected a priority conflict, got: %v", resp.Diagnostics)
+	}
+	if !strings.Contains(resp.Diagnostics[0].Detail(), "priority 2") {
+		t.Errorf("expected the detail to name the priority, got: %s", resp.Diagnostics[0].Detail())
+	}
+}
+
+func TestWebhookEventPriorityRejected(t *testing.T) {
+	tests := []struct {
+		status int
+		detail string
+		want   bool
+	}{
+		{http.StatusConflict, "Priority 1 is taken", true},
+		{http.StatusBadRequest, "Duplicate entry '1' for key 'priority'", true},
+		{http.StatusBadRequest, "Invalid value for branch", false},
+		{http.StatusForbidden, "priority cannot be changed by this user", false},
+	}
+
+	for _, test := range tests {
+		if got := webhookEventPriorityRejected(test.status, test.detail); got != test.want {
+			t.Errorf("webhookEventPriorityRejected(%d, %q) = %t, want %t", test.status, test.detail, got, test.want)
+		}
+	}
+}
+
+func TestWorkspaceWebhookEventResource_Create_SendsTagFilters(t *testing.T) {
+	ctx := context.Background()
+	s, objType := webhookEventSchemaAndType(t, ctx)
+
+	const webhookID = "wh-create-tag-1"
+
+	var capturedBody []byte
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/webhook/"+webhookID, func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `{"data":{"type":"webhook","id":%q,"attributes":{"name":"wh"},"relationships":{"events":{"data":[]}}}}`, webhookID)
+	})
+	mux.HandleFunc("/api/v1/operations", func(w http.ResponseWriter, req *http.Request) {
+		capturedBody, _ = io.ReadAll(req.Body)
+		fmt.Fprint(w, `{"atomic:results":[{"data":{"type":"webhook_event","id":"event-created-1"}}]}`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	r := &WorkspaceWebhookEventResource{client: server.Client(), endpoint: server.URL, token: "test-token"}
+
+	stringList := tftypes.List{ElementType: tftypes.String}
+	planValue := buildObjectValue(objType, map[string]tftypes.Value{
+		"webhook_id":          tftypes.NewValue(tftypes.String, webhookID),
+		"event":               tftypes.NewValue(tftypes.String, "RELEASE"),
+		"priority":            tftypes.NewValue(tftypes.Number, big.NewFloat(1)),
+		"tag":                 tftypes.NewValue(stringList, []tftypes.Value{tftypes.NewValue(tftypes.String, `v[0-9]+\.[0-9]+\.[0-9]+`), tftypes.NewValue(tftypes.String, "latest")}),
+		"pr_workflow_enabled": tftypes.NewValue(tftypes.Bool, false),
+		"pr_apply_enabled":    tftypes.NewValue(tftypes.Bool, false),
+	})
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: s}}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: planValue}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create returned diagnostics: %v", resp.Diagnostics)
+	}
+
+	if want := `"tag":"v[0-9]+\\.[0-9]+\\.[0-9]+,latest"`; !strings.Contains(string(capturedBody), want) {
+		t.Errorf("expected request body to include %s, got: %s", want, capturedBody)
+	}
+	// Terrakube releases without these filters reject unknown attributes.
+	for _, unexpected := range []string{`"sourceBranch"`, `"label"`} {
+		if strings.Contains(string(capturedBody), unexpected) {
+			t.Errorf("expected request body to omit the unset %s filter, got: %s", unexpected, capturedBody)
+		}
+	}
+}
+
+func TestWorkspaceWebhookEventResource_Read_PullRequestFilters(t *testing.T) {
+	ctx := context.Background()
+	s, objType := webhookEventSchemaAndType(t, ctx)
+
+	const eventID = "event-read-pr-1"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/webhook_event/"+eventID, func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `{"data":{"type":"webhook_event","id":%q,"attributes":{`+
+			`"branch":"main","path":"","templateId":"tmpl-1","event":"PULL_REQUEST","priority":1,`+
+			`"tag":"","sourceBranch":"feature/.*","label":"terraform,infra"},`+
+			`"relationships":{"webhook":{"data":{"type":"webhook","id":"wh-1"}}}}}`, eventID)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	r := &WorkspaceWebhookEventResource{client: server.Client(), endpoint: server.URL, token: "test-token"}
+
+	state := buildObjectValue(objType, map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, eventID),
+		"webhook_id": tftypes.NewValue(tftypes.String, "wh-1"),
+	})
+	resp := &resource.ReadResponse{State: tfsdk.State{Schema: s, Raw: state}}
+	r.Read(ctx, resource.ReadRequest{State: tfsdk.State{Schema: s, Raw: state}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read returned diagnostics: %v", resp.Diagnostics)
+	}
+
+	var result WorkspaceWebhookEventResourceModel
+	if diags := resp.State.Get(ctx, &result); diags.HasError() {
+		t.Fatalf("reading resulting state: %v", diags)
+	}
+	if !result.Tag.IsNull() {
+		t.Errorf("expected an empty tag filter to be read as null, got %s", result.Tag)
+	}
+	if len(result.SourceBranch.Elements()) != 1 || len(result.Label.Elements()) != 2 {
+		t.Errorf("unexpected source_branch %s and label %s", result.SourceBranch, result.Label)
+	}
+}
+
+func TestWorkspaceWebhookEventResource_ValidateConfig_FiltersMatchEvent(t *testing.T) {
+	ctx := context.Background()
+	s, objType := webhookEventSchemaAndType(t, ctx)
+	stringList := tftypes.List{ElementType: tftypes.String}
+	filter := tftypes.NewValue(stringList, []tftypes.Value{tftypes.NewValue(tftypes.String, "main")})
+
+	tests := []struct {
+		event  tftypes.Value
+		filter string
+		valid  bool
+	}{
+		{event: tftypes.NewValue(tftypes.String, "RELEASE"), filter: "tag", valid: true},
+		{event: tftypes.NewValue(tftypes.String, "PULL_REQUEST"), filter: "source_branch", valid: true},
+		{event: tftypes.NewValue(tftypes.String, "PULL_REQUEST"), filter: "label", valid: true},
+		{event: tftypes.NewValue(tftypes.String, tftypes.UnknownValue), filter: "tag", valid: true},
+		{event: tftypes.NewValue(tftypes.String, nil), filter: "tag"},
+		{event: tftypes.NewValue(tftypes.String, "PULL_REQUEST"), filter: "tag"},
+		{event: tftypes.NewValue(tftypes.String, "RELEASE"), filter: "label"},
+	}
+
+	for _, test := range tests {
+		config := buildObjectValue(objType, map[string]tftypes.Value{
+			"webhook_id": tftypes.NewValue(tftypes.String, "wh-1"),
+			"event":      test.event,
+			test.filter:  filter,
+		})
+		resp := &resource.ValidateConfigResponse{}
+		(&WorkspaceWebhookEventResource{}).ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: s, Raw: config}}, resp)
+		if resp.Diagnostics.HasError() == test.valid {
+			t.Errorf("%s on %s: expected valid=%t, got %v", test.filter, test.event, test.valid, resp.Diagnostics)
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/google/jsonapi"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
@@ -78,11 +79,17 @@ func (r *WorkspaceWebhookResource) Schema(ctx context.Context, req resource.Sche
 				Optional:    true,
 				Description: "The file paths in regex that trigger a run.",
 				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(webhookEventFilterValidator{}),
+				},
 			},
 			"branch": schema.ListAttribute{
 				Optional:    true,
 				Description: "A list of branches that trigger a run. Support regex for more complex matching.",
 				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(webhookEventFilterValidator{}),
+				},
 			},
 			"template_id": schema.StringAttribute{
 				Optional:    true,
@@ -389,12 +396,24 @@ func (r *WorkspaceWebhookResource) Delete(ctx context.Context, req resource.Dele
 }
 
 func (r *WorkspaceWebhookResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	steps := []importStep{organizationImportStep, workspaceImportStep, {
+		attribute:  "id",
+		kind:       "Webhook",
+		collection: "organization/%s/workspace/%s/webhook",
+		filter:     "webhook",
+		entity:     new(client.WorkspaceWebhookEntity),
+	}}
+	api := importAPI{client: r.client, endpoint: r.endpoint, token: r.token}
+	if api.importByName(ctx, req, resp, steps...) {
+		return
+	}
+
 	idParts := strings.Split(req.ID, ",")
 
 	if len(idParts) != 3 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: 'organization_ID,workspace_ID, ID', Got: %q", req.ID),
+			fmt.Sprintf("Expected import identifier with format: 'organization_ID,workspace_ID, ID' or '%s', Got: %q", importNamesFormat(steps...), req.ID),
 		)
 		return
 	}
@@ -0,0 +1,435 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var webhookV2EventAttrTypes = map[string]attr.Type{
+	"id":                  types.StringType,
+	"event":               types.StringType,
+	"branch":              types.ListType{ElemType: types.StringType},
+	"path":                types.ListType{ElemType: types.StringType},
+	"tag":                 types.ListType{ElemType: types.StringType},
+	"source_branch":       types.ListType{ElemType: types.StringType},
+	"label":               types.ListType{ElemType: types.StringType},
+	"priority":            types.Int64Type,
+	"template_id":         types.StringType,
+	"pr_workflow_enabled": types.BoolType,
+	"pr_apply_enabled":    types.BoolType,
+}
+
+// webhookV2EventModel is an event block of terrakube_workspace_webhook_v2,
+// the inline counterpart of terrakube_workspace_webhook_event.
+type webhookV2EventModel struct {
+	ID                types.String `tfsdk:"id"`
+	Event             types.String `tfsdk:"event"`
+	Branch            types.List   `tfsdk:"branch"`
+	Path              types.List   `tfsdk:"path"`
+	Tag               types.List   `tfsdk:"tag"`
+	SourceBranch      types.List   `tfsdk:"source_branch"`
+	Label             types.List   `tfsdk:"label"`
+	Priority          types.Int64  `tfsdk:"priority"`
+	TemplateId        types.String `tfsdk:"template_id"`
+	PrWorkflowEnabled types.Bool   `tfsdk:"pr_workflow_enabled"`
+	PrApplyEnabled    types.Bool   `tfsdk:"pr_apply_enabled"`
+}
+
+func webhookV2EventsValue(ctx context.Context, events []webhookV2EventModel) (types.List, diag.Diagnostics) {
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: webhookV2EventAttrTypes}, events)
+}
+
+// webhookV2Events returns the event blocks of a plan or state, none when the
+// list is null, as in state written before event blocks existed.
+func webhookV2Events(ctx context.Context, list types.List) ([]webhookV2EventModel, diag.Diagnostics) {
+	var events []webhookV2EventModel
+	if list.IsNull() || list.IsUnknown() {
+		return events, nil
+	}
+	diags := list.ElementsAs(ctx, &events, false)
+	return events, diags
+}
+
+// webhookV2EventPriority is the priority of an event block, its position,
+// counting from 1, when the priority isn't set.
+func webhookV2EventPriority(event webhookV2EventModel, index int) int64 {
+	if event.Priority.IsNull() || event.Priority.IsUnknown() {
+		return int64(index + 1)
+	}
+	return event.Priority.ValueInt64()
+}
+
+// webhookV2EventAttributes returns the attributes of an event as sent in an
+// atomic operation, prior is the current event it updates, if any.
+func webhookV2EventAttributes(ctx context.Context, event, prior webhookV2EventModel) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var branchList, pathList []string
+	if !event.Branch.IsNull() && !event.Branch.IsUnknown() {
+		diags.Append(event.Branch.ElementsAs(ctx, &branchList, false)...)
+	}
+	if !event.Path.IsNull() && !event.Path.IsUnknown() {
+		diags.Append(event.Path.ElementsAs(ctx, &pathList, false)...)
+	}
+	filters, filterDiags := webhookEventFilterValues(ctx, event.Tag, event.SourceBranch, event.Label)
+	diags.Append(filterDiags...)
+	priorFilters, filterDiags := webhookEventFilterValues(ctx, prior.Tag, prior.SourceBranch, prior.Label)
+	diags.Append(filterDiags...)
+
+	attributes := map[string]interface{}{
+		"priority":          event.Priority.ValueInt64(),
+		"event":             event.Event.ValueString(),
+		"branch":            strings.Join(branchList, ","),
+		"path":              strings.Join(pathList, ","),
+		"templateId":        event.TemplateId.ValueString(),
+		"prWorkflowEnabled": event.PrWorkflowEnabled.ValueBool(),
+		"prApplyEnabled":    event.PrApplyEnabled.ValueBool(),
+	}
+	setWebhookEventFilterAttributes(attributes, filters, priorFilters)
+	return attributes, diags
+}
+
+// webhookV2EventPending reports whether the event is in state but not yet
+// created, like the event of a webhook moved from terrakube_workspace_webhook.
+func webhookV2EventPending(event webhookV2EventModel) bool {
+	return event.ID.IsNull() || event.ID.ValueString() == ""
+}
+
+// pairWebhookV2Events returns, for each planned event, the index of the
+// current event it updates or -1 when it is added. Events are paired by
+// priority, so adding or removing a block doesn't rewrite the other events,
+// and planned events whose priority changed take the current events left
+// over in order. Pending current events are never paired.
+func pairWebhookV2Events(planned, current []webhookV2EventModel) []int {
+	pairs := make([]int, len(planned))
+	paired := make([]bool, len(current))
+	for i, event := range planned {
+		pairs[i] = slices.IndexFunc(current, func(c webhookV2EventModel) bool {
+			return !webhookV2EventPending(c) && c.Priority.ValueInt64() == webhookV2EventPriority(event, i)
+		})
+		if pairs[i] >= 0 {
+			paired[pairs[i]] = true
+		}
+	}
+
+	next := 0
+	for i := range planned {
+		if pairs[i] >= 0 {
+			continue
+		}
+		for next < len(current) && (paired[next] || webhookV2EventPending(current[next])) {
+			next++
+		}
+		if next < len(current) {
+			pairs[i] = next
+			paired[next] = true
+		}
+	}
+	return pairs
+}
+
+// webhookV2EventChanged reports whether a planned event differs from the
+// current event it updates.
+func webhookV2EventChanged(event, current webhookV2EventModel) bool {
+	return !event.Event.Equal(current.Event) ||
+		!event.Branch.Equal(current.Branch) ||
+		!event.Path.Equal(current.Path) ||
+		!event.Tag.Equal(current.Tag) ||
+		!event.SourceBranch.Equal(current.SourceBranch) ||
+		!event.Label.Equal(current.Label) ||
+		!event.Priority.Equal(current.Priority) ||
+		!event.TemplateId.Equal(current.TemplateId) ||
+		!event.PrWorkflowEnabled.Equal(current.PrWorkflowEnabled) ||
+		!event.PrApplyEnabled.Equal(current.PrApplyEnabled)
+}
+
+// webhookV2EventOperations reconciles the events of a webhook in a single
+// list of atomic operations. Planned events are paired with the current ones
+// by pairWebhookV2Events: paired events that changed are updated in place,
+// the other planned events added and the current events left over removed.
+// The planned events are returned with their ids.
+//
+// Terrakube rejects two events of a webhook with the same priority after
+// every operation, so events are removed first, every updated event whose
+// priority changes is then moved to a free priority above all others, so
+// shifted and swapped priorities never collide, before the updates, and
+// events are added last.
+func webhookV2EventOperations(ctx context.Context, eventsHref string, planned, current []webhookV2EventModel) ([]map[string]interface{}, []webhookV2EventModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var removes, moves, updates, adds []map[string]interface{}
+	events := slices.Clone(planned)
+	pairs := pairWebhookV2Events(planned, current)
+
+	free := int64(0)
+	for i := range events {
+		events[i].Priority = types.Int64Value(webhookV2EventPriority(events[i], i))
+		free = max(free, events[i].Priority.ValueInt64())
+	}
+	for _, event := range current {
+		free = max(free, event.Priority.ValueInt64())
+	}
+
+	for i := range events {
+		if pairs[i] >= 0 {
+			prior := current[pairs[i]]
+			events[i].ID = prior.ID
+			if !webhookV2EventChanged(events[i], prior) {
+				continue
+			}
+			attributes, attributeDiags := webhookV2EventAttributes(ctx, events[i], prior)
+			diags.Append(attributeDiags...)
+			href := fmt.Sprintf("%s/%s", eventsHref, prior.ID.ValueString())
+			if !events[i].Priority.Equal(prior.Priority) {
+				free++
+				moves = append(moves, webhookV2EventUpdate(href, prior.ID.ValueString(), map[string]interface{}{"priority": free}))
+			}
+			updates = append(updates, webhookV2EventUpdate(href, prior.ID.ValueString(), attributes))
+			continue
+		}
+
+		attributes, attributeDiags := webhookV2EventAttributes(ctx, events[i], webhookV2EventModel{})
+		diags.Append(attributeDiags...)
+		events[i].ID = types.StringValue(uuid.New().String())
+		adds = append(adds, map[string]interface{}{
+			"op":   "add",
+			"href": eventsHref,
+			"data": map[string]interface{}{
+				"type":       "webhook_event",
+				"id":         events[i].ID.ValueString(),
+				"attributes": attributes,
+			},
+		})
+	}
+
+	for j, event := range current {
+		if webhookV2EventPending(event) || slices.Contains(pairs, j) {
+			continue
+		}
+		removes = append(removes, map[string]interface{}{
+			"op":   "remove",
+			"href": fmt.Sprintf("%s/%s", eventsHref, event.ID.ValueString()),
+		})
+	}
+
+	return slices.Concat(removes, moves, updates, adds), events, diags
+}
+
+// webhookV2EventUpdate returns the atomic operation updating the attributes
+// of an event.
+func webhookV2EventUpdate(href, id string, attributes map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"op":   "update",
+		"href": href,
+		"data": map[string]interface{}{
+			"type":       "webhook_event",
+			"id":         id,
+			"attributes": attributes,
+		},
+	}
+}
+
+// sortWebhookV2Events orders the events read from the API like the prior
+// state, with events not in state, created outside of Terraform, last by
+// priority.
+func sortWebhookV2Events(events, prior []webhookV2EventModel) {
+	position := func(event webhookV2EventModel) int {
+		index := slices.IndexFunc(prior, func(p webhookV2EventModel) bool { return p.ID.Equal(event.ID) })
+		if index < 0 {
+			return len(prior)
+		}
+		return index
+	}
+
+	slices.SortStableFunc(events, func(a, b webhookV2EventModel) int {
+		if byPosition := position(a) - position(b); byPosition != 0 {
+			return byPosition
+		}
+		return int(a.Priority.ValueInt64() - b.Priority.ValueInt64())
+	})
+}
+
+// webhookV2EventList converts comma separated filters to a list, an
+// empty value is null like an unset block attribute.
+func webhookV2EventList(ctx context.Context, value string) (types.List, diag.Diagnostics) {
+	if value == "" {
+		return types.ListNull(types.StringType), nil
+	}
+	return types.ListValueFrom(ctx, types.StringType, strings.Split(value, ","))
+}
+
+// readEvents returns the events of a webhook ordered by priority.
+func (r *WorkspaceWebhookV2Resource) readEvents(ctx context.Context, organizationID, workspaceID, webhookID string) ([]webhookV2EventModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v1/organization/%s/workspace/%s/webhook/%s/events", r.endpoint, organizationID, workspaceID, webhookID), nil)
+	if err != nil {
+		diags.AddError("Error creating webhook event read request", fmt.Sprintf("Error creating webhook event read request: %s", err))
+		return nil, diags
+	}
+	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", r.token))
+	request.Header.Add("Content-Type", "application/vnd.api+json")
+
+	response, err := r.client.Do(request)
+	if err != nil {
+		diags.AddError("Error executing webhook event read request", fmt.Sprintf("Error executing webhook event read request: %s", err))
+		return nil, diags
+	}
+	defer response.Body.Close()
+
+	bodyResponse, err := io.ReadAll(response.Body)
+	if err != nil {
+		diags.AddError("Error reading webhook event response", fmt.Sprintf("Error reading webhook event response: %s", err))
+		return nil, diags
+	}
+
+	if response.StatusCode != http.StatusOK {
+		diags.AddError("Error reading webhook events", fmt.Sprintf("Received non-200 status code: %d, body: %s", response.StatusCode, string(bodyResponse)))
+		return nil, diags
+	}
+
+	var eventsResp webhookEventAPIResponse
+	if err := json.Unmarshal(bodyResponse, &eventsResp); err != nil {
+		diags.AddError("Error unmarshal payload response", fmt.Sprintf("Error unmarshal payload response: %s", err))
+		return nil, diags
+	}
+
+	events := []webhookV2EventModel{}
+	for _, data := range eventsResp.Data {
+		branch, branchDiags := webhookV2EventList(ctx, data.Attributes.Branch)
+		diags.Append(branchDiags...)
+		eventPath, pathDiags := webhookV2EventList(ctx, data.Attributes.Path)
+		diags.Append(pathDiags...)
+		tag, tagDiags := webhookV2EventList(ctx, data.Attributes.Tag)
+		diags.Append(tagDiags...)
+		sourceBranch, sourceBranchDiags := webhookV2EventList(ctx, data.Attributes.SourceBranch)
+		diags.Append(sourceBranchDiags...)
+		label, labelDiags := webhookV2EventList(ctx, data.Attributes.Label)
+		diags.Append(labelDiags...)
+
+		events = append(events, webhookV2EventModel{
+			ID:                types.StringValue(data.ID),
+			Event:             types.StringValue(data.Attributes.Event),
+			Branch:            branch,
+			Path:              eventPath,
+			Tag:               tag,
+			SourceBranch:      sourceBranch,
+			Label:             label,
+			Priority:          types.Int64Value(int64(data.Attributes.Priority)),
+			TemplateId:        types.StringValue(data.Attributes.TemplateId),
+			PrWorkflowEnabled: types.BoolValue(data.Attributes.PrWorkflowEnabled),
+			PrApplyEnabled:    types.BoolValue(data.Attributes.PrApplyEnabled),
+		})
+	}
+	sortWebhookV2Events(events, nil)
+	return events, diags
+}
+
+// postAtomicOperations sends the operations in a single atomic request, so
+// either all of them or none are applied.
+func (r *WorkspaceWebhookV2Resource) postAtomicOperations(ctx context.Context, operations []map[string]interface{}) (AtomicOperationResponse, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var atomicResp AtomicOperationResponse
+
+	jsonData, err := json.Marshal(map[string]interface{}{"atomic:operations": operations})
+	if err != nil {
+		tflog.Error(ctx, "Failed to marshal webhook payload", map[string]any{
+			"error": err.Error(),
+		})
+		diags.AddError("Unable to marshal payload", fmt.Sprintf("Unable to marshal payload: %s", err))
+		return atomicResp, diags
+	}
+
+	tflog.Debug(ctx, "Marshaled webhook payload", map[string]any{
+		"payload": string(jsonData),
+	})
+
+	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v1/operations", r.endpoint), strings.NewReader(string(jsonData)))
+	if err != nil {
+		tflog.Error(ctx, "Failed to create webhook request", map[string]any{
+			"error": err.Error(),
+		})
+		diags.AddError("Error creating workspace webhook resource request", fmt.Sprintf("Error creating workspace webhook resource request %s", err))
+		return atomicResp, diags
+	}
+
+	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", r.token))
+	request.Header.Set("Content-Type", "application/vnd.api+json;ext=\"https://jsonapi.org/ext/atomic\"")
+	request.Header.Set("Accept", "application/vnd.api+json;ext=\"https://jsonapi.org/ext/atomic\"")
+
+	response, err := r.client.Do(request)
+	if err != nil {
+		tflog.Error(ctx, "Failed to execute webhook request", map[string]any{
+			"error": err.Error(),
+		})
+		diags.AddError("Error executing workspace webhook resource request", fmt.Sprintf("Error executing workspace webhook resource request: %s", err))
+		return atomicResp, diags
+	}
+	defer response.Body.Close()
+
+	bodyResponse, err := io.ReadAll(response.Body)
+	if err != nil {
+		tflog.Error(ctx, fmt.Sprintf("Error reading workspace webhook resource, response status %s, error: %s", response.Status, err))
+	}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		var errorResp ErrorResponse
+		if err := json.Unmarshal(bodyResponse, &errorResp); err != nil || len(errorResp.Errors) == 0 {
+			tflog.Error(ctx, "Failed to parse error response", map[string]any{
+				"body": string(bodyResponse),
+			})
+			diags.AddError(
+				fmt.Sprintf("Failed to create/update webhook: %s", response.Status),
+				string(bodyResponse),
+			)
+			return atomicResp, diags
+		}
+
+		tflog.Error(ctx, "API returned error status", map[string]any{
+			"status_code": response.StatusCode,
+			"body":        string(bodyResponse),
+		})
+		// Decode HTML entities in the error message
+		decodedDetail := html.UnescapeString(errorResp.Errors[0].Detail)
+		if webhookEventPriorityRejected(response.StatusCode, decodedDetail) {
+			diags.AddAttributeError(path.Root("event"), webhookEventPriorityConflictSummary,
+				fmt.Sprintf("The webhook already has an event with the same priority: %s. Priorities must be unique within a webhook.", decodedDetail))
+			return atomicResp, diags
+		}
+		diags.AddError(
+			"Failed to create/update webhook",
+			decodedDetail,
+		)
+		return atomicResp, diags
+	}
+
+	tflog.Debug(ctx, "Received webhook response", map[string]any{
+		"status_code": response.StatusCode,
+		"body":        string(bodyResponse),
+	})
+
+	if err := json.Unmarshal(bodyResponse, &atomicResp); err != nil {
+		tflog.Error(ctx, "Failed to parse atomic operation response", map[string]any{
+			"error": err.Error(),
+			"body":  string(bodyResponse),
+		})
+		diags.AddError(
+			"Failed to parse webhook response",
+			fmt.Sprintf("Error parsing webhook response: %s", err),
+		)
+		return atomicResp, diags
+	}
+
+	return atomicResp, diags
+}
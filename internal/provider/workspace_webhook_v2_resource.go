@@ -6,17 +6,22 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
-	"html"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
 	"terraform-provider-terrakube/internal/client"
 
 	"github.com/google/jsonapi"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -27,7 +32,11 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &WorkspaceWebhookV2Resource{}
+var _ resource.ResourceWithIdentity = &WorkspaceWebhookV2Resource{}
 var _ resource.ResourceWithImportState = &WorkspaceWebhookV2Resource{}
+var _ resource.ResourceWithModifyPlan = &WorkspaceWebhookV2Resource{}
+var _ resource.ResourceWithValidateConfig = &WorkspaceWebhookV2Resource{}
+var _ resource.ResourceWithMoveState = &WorkspaceWebhookV2Resource{}
 
 type WorkspaceWebhookV2Resource struct {
 	client   *http.Client
@@ -41,6 +50,7 @@ type WorkspaceWebhookV2ResourceModel struct {
 	WorkspaceId    types.String `tfsdk:"workspace_id"`
 	RemoteHookId   types.String `tfsdk:"remote_hook_id"`
 	MigratedV2     types.Bool   `tfsdk:"migrated_v2"`
+	Events         types.List   `tfsdk:"event"`
 }
 
 func NewWorkspaceWebhookV2Resource() resource.Resource {
@@ -85,6 +95,90 @@ func (r *WorkspaceWebhookV2Resource) Schema(ctx context.Context, req resource.Sc
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"event": schema.ListNestedBlock{
+				Description: "Events that trigger the webhook, managed together in a single atomic request. When event blocks are set, " +
+					"they replace every event of the webhook, so don't combine them with `terrakube_workspace_webhook_event` resources for the same webhook.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "Webhook Event ID",
+						},
+						"event": schema.StringAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString("PUSH"),
+							Description: "The event type that triggers a run. Supported values: `PUSH`, `PULL_REQUEST`, `RELEASE`.",
+							Validators: []validator.String{
+								stringvalidator.OneOf("PUSH", "PULL_REQUEST", "RELEASE"),
+							},
+						},
+						"branch": schema.ListAttribute{
+							Optional:    true,
+							Description: "A list of branches that trigger a run, the target branch for `PULL_REQUEST` events. Support regex for more complex matching.",
+							ElementType: types.StringType,
+							Validators: []validator.List{
+								listvalidator.ValueStringsAre(webhookEventFilterValidator{}),
+							},
+						},
+						"path": schema.ListAttribute{
+							Optional:    true,
+							Description: "The file paths in regex that trigger a run.",
+							ElementType: types.StringType,
+							Validators: []validator.List{
+								listvalidator.ValueStringsAre(webhookEventFilterValidator{}),
+							},
+						},
+						"tag": schema.ListAttribute{
+							Optional:    true,
+							Description: "The tags in regex that trigger a run, for example `v[0-9]+\\.[0-9]+\\.[0-9]+` for semver tags (`RELEASE` events only). Requires a Terrakube release supporting tag filters; older instances will reject it.",
+							ElementType: types.StringType,
+							Validators: []validator.List{
+								listvalidator.ValueStringsAre(webhookEventFilterValidator{}),
+							},
+						},
+						"source_branch": schema.ListAttribute{
+							Optional:    true,
+							Description: "The source branches in regex of the pull requests that trigger a run (`PULL_REQUEST` events only). Requires a Terrakube release supporting source branch filters; older instances will reject it.",
+							ElementType: types.StringType,
+							Validators: []validator.List{
+								listvalidator.ValueStringsAre(webhookEventFilterValidator{}),
+							},
+						},
+						"label": schema.ListAttribute{
+							Optional:    true,
+							Description: "The labels in regex that trigger a run, a pull request triggers it when one of its labels matches (`PULL_REQUEST` events only). Requires a Terrakube release supporting label filters; older instances will reject it.",
+							ElementType: types.StringType,
+							Validators: []validator.List{
+								listvalidator.ValueStringsAre(webhookEventFilterValidator{}),
+							},
+						},
+						"priority": schema.Int64Attribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "The priority of this webhook event, unique within the webhook. Defaults to the position of the block, starting at 1. Event blocks are matched with the existing events by priority, so set it to leave the other events untouched when a block is inserted or removed.",
+						},
+						"template_id": schema.StringAttribute{
+							Optional:    true,
+							Description: "The template id to use for the run.",
+						},
+						"pr_workflow_enabled": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+							Description: "Post plan results as a comment on the pull/merge request (`PULL_REQUEST` events only), and accept a `terrakube plan` PR-comment command to re-run it.",
+						},
+						"pr_apply_enabled": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+							Description: "Allow the `terrakube apply` PR-comment command to apply this workspace (`PULL_REQUEST` events only). Requires `pr_workflow_enabled` to also be true.",
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -136,116 +230,43 @@ func (r *WorkspaceWebhookV2Resource) Create(ctx context.Context, req resource.Cr
 	})
 
 	migratedV2 := plan.MigratedV2.ValueBool()
-	atomicOperation := map[string]interface{}{
-		"atomic:operations": []map[string]interface{}{
-			{
-				"op":   "add",
-				"href": fmt.Sprintf("/organization/%s/workspace/%s/webhook", plan.OrganizationId.ValueString(), plan.WorkspaceId.ValueString()),
-				"data": map[string]interface{}{
-					"type": "webhook",
-					"id":   webhookID,
-					"attributes": map[string]interface{}{
-						"migratedV2": migratedV2,
-					},
+	operations := []map[string]interface{}{
+		{
+			"op":   "add",
+			"href": fmt.Sprintf("/organization/%s/workspace/%s/webhook", plan.OrganizationId.ValueString(), plan.WorkspaceId.ValueString()),
+			"data": map[string]interface{}{
+				"type": "webhook",
+				"id":   webhookID,
+				"attributes": map[string]interface{}{
+					"migratedV2": migratedV2,
 				},
-				"relationships": map[string]interface{}{
-					"workspace": map[string]interface{}{
-						"data": map[string]interface{}{
-							"type": "workspace",
-							"id":   plan.WorkspaceId.ValueString(),
-						},
+			},
+			"relationships": map[string]interface{}{
+				"workspace": map[string]interface{}{
+					"data": map[string]interface{}{
+						"type": "workspace",
+						"id":   plan.WorkspaceId.ValueString(),
 					},
 				},
 			},
 		},
 	}
 
-	jsonData, err := json.Marshal(atomicOperation)
-	if err != nil {
-		tflog.Error(ctx, "Failed to marshal webhook payload", map[string]any{
-			"error": err.Error(),
-		})
-		resp.Diagnostics.AddError("Unable to marshal payload", fmt.Sprintf("Unable to marshal payload: %s", err))
-		return
-	}
-
-	tflog.Debug(ctx, "Marshaled webhook payload", map[string]any{
-		"payload": string(jsonData),
-	})
-
-	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v1/operations", r.endpoint), strings.NewReader(string(jsonData)))
-	if err != nil {
-		tflog.Error(ctx, "Failed to create webhook request", map[string]any{
-			"error": err.Error(),
-		})
-		resp.Diagnostics.AddError("Error creating workspace webhook resource request", fmt.Sprintf("Error creating workspace webhook resource request %s", err))
-		return
-	}
-
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", r.token))
-	request.Header.Set("Content-Type", "application/vnd.api+json;ext=\"https://jsonapi.org/ext/atomic\"")
-	request.Header.Set("Accept", "application/vnd.api+json;ext=\"https://jsonapi.org/ext/atomic\"")
-
-	response, err := r.client.Do(request)
-	if err != nil {
-		tflog.Error(ctx, "Failed to execute webhook request", map[string]any{
-			"error": err.Error(),
-		})
-		resp.Diagnostics.AddError("Error executing workspace webhook resource request", fmt.Sprintf("Error executing workspace webhook resource request: %s", err))
+	planned, diags := webhookV2Events(ctx, plan.Events)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-
-	bodyResponse, err := io.ReadAll(response.Body)
-	if err != nil {
-		tflog.Error(ctx, "Failed to read webhook response", map[string]any{
-			"error": err.Error(),
-		})
-		tflog.Error(ctx, fmt.Sprintf("Error reading workspace webhook resource, response status %s, error: %s", response.Status, err))
-	}
-
-	if response.StatusCode < 200 || response.StatusCode >= 300 {
-		var errorResp ErrorResponse
-		if err := json.Unmarshal(bodyResponse, &errorResp); err != nil {
-			tflog.Error(ctx, "Failed to parse error response", map[string]any{
-				"error": err.Error(),
-				"body":  string(bodyResponse),
-			})
-			resp.Diagnostics.AddError(
-				fmt.Sprintf("Failed to create/update webhook: %s", response.Status),
-				string(bodyResponse),
-			)
-			return
-		}
-
-		// Decode HTML entities in the error message
-		decodedDetail := html.UnescapeString(errorResp.Errors[0].Detail)
-
-		tflog.Error(ctx, "API returned error status", map[string]any{
-			"status_code": response.StatusCode,
-			"body":        string(bodyResponse),
-		})
-		resp.Diagnostics.AddError(
-			"Failed to create/update webhook",
-			decodedDetail,
-		)
+	eventOperations, events, diags := webhookV2EventOperations(ctx, fmt.Sprintf("/organization/%s/workspace/%s/webhook/%s/events", plan.OrganizationId.ValueString(), plan.WorkspaceId.ValueString(), webhookID), planned, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	operations = append(operations, eventOperations...)
 
-	tflog.Debug(ctx, "Received webhook response", map[string]any{
-		"status_code": response.StatusCode,
-		"body":        string(bodyResponse),
-	})
-
-	var atomicResp AtomicOperationResponse
-	if err := json.Unmarshal(bodyResponse, &atomicResp); err != nil {
-		tflog.Error(ctx, "Failed to parse atomic operation response", map[string]any{
-			"error": err.Error(),
-			"body":  string(bodyResponse),
-		})
-		resp.Diagnostics.AddError(
-			"Failed to parse webhook response",
-			fmt.Sprintf("Error parsing webhook response: %s", err),
-		)
+	atomicResp, diags := r.postAtomicOperations(ctx, operations)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -262,17 +283,30 @@ func (r *WorkspaceWebhookV2Resource) Create(ctx context.Context, req resource.Cr
 	plan.RemoteHookId = types.StringValue(result.Data.ID) // Using ID as RemoteHookId since it's not in the response
 	plan.MigratedV2 = types.BoolValue(migratedV2)
 
+	// The events follow the webhook in the results, in the order they were sent.
+	for i, eventResult := range atomicResp.AtomicResults[1:] {
+		if i < len(events) && eventResult.Data.ID != "" {
+			events[i].ID = types.StringValue(eventResult.Data.ID)
+		}
+	}
+	plan.Events, diags = webhookV2EventsValue(ctx, events)
+	resp.Diagnostics.Append(diags...)
+
 	tflog.Debug(ctx, "Successfully created webhook", map[string]any{
 		"id":             result.Data.ID,
 		"remote_hook_id": result.Data.ID,
+		"events":         len(events),
 	})
 
 	tflog.Info(ctx, "Workspace Webhook Resource Created", map[string]any{"success": true})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *WorkspaceWebhookV2Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.Diagnostics.Append(setResourceIdentity(ctx, req.State, resp.Identity)...)
+
 	var state WorkspaceWebhookV2ResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -337,6 +371,32 @@ func (r *WorkspaceWebhookV2Resource) Read(ctx context.Context, req resource.Read
 	state.RemoteHookId = types.StringValue(responseData.Data.Attributes.RemoteHookId)
 	state.MigratedV2 = types.BoolValue(responseData.Data.Attributes.MigratedV2)
 
+	// Events are only read back while they are managed with event blocks, so
+	// terrakube_workspace_webhook_event resources don't show up as drift.
+	prior, diags := webhookV2Events(ctx, state.Events)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	events := []webhookV2EventModel{}
+	if len(prior) > 0 {
+		events, diags = r.readEvents(ctx, state.OrganizationId.ValueString(), state.WorkspaceId.ValueString(), state.ID.ValueString())
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		sortWebhookV2Events(events, prior)
+
+		// Pending events only exist in state until the next apply creates them.
+		for _, event := range prior {
+			if webhookV2EventPending(event) {
+				events = append(events, event)
+			}
+		}
+	}
+	state.Events, diags = webhookV2EventsValue(ctx, events)
+	resp.Diagnostics.Append(diags...)
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -356,6 +416,10 @@ func (r *WorkspaceWebhookV2Resource) Update(ctx context.Context, req resource.Up
 		return
 	}
 
+	// The events are reconciled against state, terrakube_workspace_webhook_event
+	// resources of the same webhook lock it too.
+	defer lockParent(ctx, "webhook", state.ID.ValueString())()
+
 	migratedV2 := plan.MigratedV2.ValueBool()
 	bodyRequest := &client.WorkspaceWebhookV2Entity{
 		ID:         state.ID.ValueString(),
@@ -446,7 +510,31 @@ func (r *WorkspaceWebhookV2Resource) Update(ctx context.Context, req resource.Up
 	plan.RemoteHookId = types.StringValue(responseData.Data.Attributes.RemoteHookId)
 	plan.MigratedV2 = types.BoolValue(responseData.Data.Attributes.MigratedV2)
 
+	planned, diags := webhookV2Events(ctx, plan.Events)
+	resp.Diagnostics.Append(diags...)
+	current, currentDiags := webhookV2Events(ctx, state.Events)
+	resp.Diagnostics.Append(currentDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	eventOperations, events, diags := webhookV2EventOperations(ctx, fmt.Sprintf("/organization/%s/workspace/%s/webhook/%s/events", state.OrganizationId.ValueString(), state.WorkspaceId.ValueString(), state.ID.ValueString()), planned, current)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(eventOperations) > 0 {
+		_, diags = r.postAtomicOperations(ctx, eventOperations)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	plan.Events, diags = webhookV2EventsValue(ctx, events)
+	resp.Diagnostics.Append(diags...)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *WorkspaceWebhookV2Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -459,6 +547,8 @@ func (r *WorkspaceWebhookV2Resource) Delete(ctx context.Context, req resource.De
 		return
 	}
 
+	defer lockParent(ctx, "webhook", data.ID.ValueString())()
+
 	request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/api/v1/organization/%s/workspace/%s/webhook/%s", r.endpoint, data.OrganizationId.ValueString(), data.WorkspaceId.ValueString(), data.ID.ValueString()), nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating workspace webhook resource request", fmt.Sprintf("Error creating workspace webhook resource request: %s", err))
@@ -478,13 +568,190 @@ func (r *WorkspaceWebhookV2Resource) Delete(ctx context.Context, req resource.De
 	}
 }
 
+func (r *WorkspaceWebhookV2Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Do nothing if it's destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan WorkspaceWebhookV2ResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Events.IsUnknown() {
+		return
+	}
+
+	events, diags := webhookV2Events(ctx, plan.Events)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for i := range events {
+		if events[i].Priority.IsUnknown() {
+			events[i].Priority = types.Int64Value(int64(i + 1))
+		}
+		events[i].ID = types.StringUnknown()
+	}
+
+	// A webhook moved from terrakube_workspace_webhook is switched to the v2
+	// processing path when its pending events are created, unless migrated_v2
+	// is set in the configuration. Without event blocks nothing creates them,
+	// so the webhook would be switched without any event.
+	if !req.State.Raw.IsNull() {
+		var state WorkspaceWebhookV2ResourceModel
+		var migratedV2 types.Bool
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("migrated_v2"), &migratedV2)...)
+		current, diags := webhookV2Events(ctx, state.Events)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		// Events keep the id of the current event they update, see
+		// pairWebhookV2Events, the others are created.
+		for i, j := range pairWebhookV2Events(events, current) {
+			if j >= 0 {
+				events[i].ID = current[j].ID
+			}
+		}
+		if migratedV2.IsNull() && slices.ContainsFunc(current, webhookV2EventPending) {
+			if len(events) == 0 {
+				resp.Diagnostics.AddAttributeError(path.Root("event"), "Missing Webhook Event",
+					"This webhook was moved from terrakube_workspace_webhook and its event is only created from an event block. "+
+						"Add an event block, or set migrated_v2 if the events of the webhook are managed with terrakube_workspace_webhook_event resources.")
+				return
+			}
+			plan.MigratedV2 = types.BoolValue(true)
+		}
+	}
+
+	plan.Events, diags = webhookV2EventsValue(ctx, events)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *WorkspaceWebhookV2Resource) MoveState(ctx context.Context) []resource.StateMover {
+	var legacySchema resource.SchemaResponse
+	NewWorkspaceWebhookResource().Schema(ctx, resource.SchemaRequest{}, &legacySchema)
+
+	return []resource.StateMover{
+		{
+			SourceSchema: &legacySchema.Schema,
+			StateMover:   r.moveLegacyWebhookState,
+		},
+	}
+}
+
+// moveLegacyWebhookState moves a terrakube_workspace_webhook, which keeps its
+// path, branch, template and event on the webhook itself, to a webhook v2 with
+// a single event block. The event is pending until the next apply creates it
+// and sets migrated_v2, no request is sent while moving.
+func (r *WorkspaceWebhookV2Resource) moveLegacyWebhookState(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	if req.SourceTypeName != "terrakube_workspace_webhook" || req.SourceState == nil {
+		return
+	}
+
+	var legacy WorkspaceWebhookResourceModel
+	resp.Diagnostics.Append(req.SourceState.Get(ctx, &legacy)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	event := legacy.Event
+	if event.IsNull() || event.ValueString() == "" {
+		event = types.StringValue("PUSH")
+	}
+	events, diags := webhookV2EventsValue(ctx, []webhookV2EventModel{{
+		ID:                types.StringNull(),
+		Event:             event,
+		Branch:            legacy.Branch,
+		Path:              legacy.Path,
+		Tag:               types.ListNull(types.StringType),
+		SourceBranch:      types.ListNull(types.StringType),
+		Label:             types.ListNull(types.StringType),
+		Priority:          types.Int64Value(1),
+		TemplateId:        legacy.TemplateId,
+		PrWorkflowEnabled: types.BoolValue(false),
+		PrApplyEnabled:    types.BoolValue(false),
+	}})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Moving legacy workspace webhook to webhook v2", map[string]any{"id": legacy.ID.ValueString()})
+
+	resp.Diagnostics.Append(resp.TargetState.Set(ctx, WorkspaceWebhookV2ResourceModel{
+		ID:             legacy.ID,
+		OrganizationId: legacy.OrganizationId,
+		WorkspaceId:    legacy.WorkspaceId,
+		RemoteHookId:   legacy.RemoteHookId,
+		MigratedV2:     types.BoolValue(false),
+		Events:         events,
+	})...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.TargetState, resp.TargetIdentity)...)
+}
+
+func (r *WorkspaceWebhookV2Resource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config WorkspaceWebhookV2ResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	events, diags := webhookV2Events(ctx, config.Events)
+	resp.Diagnostics.Append(diags...)
+
+	priorities := map[int64]int{}
+	for i, event := range events {
+		resp.Diagnostics.Append(validateWebhookEventFilters(event.Event, map[string]types.List{
+			"tag":           event.Tag,
+			"source_branch": event.SourceBranch,
+			"label":         event.Label,
+		}, path.Root("event").AtListIndex(i).AtName)...)
+
+		if event.Priority.IsUnknown() {
+			continue
+		}
+		priority := webhookV2EventPriority(event, i)
+		if previous, ok := priorities[priority]; ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("event").AtListIndex(i).AtName("priority"),
+				"Duplicate Webhook Event Priority",
+				fmt.Sprintf("Event blocks %d and %d both have priority %d, priorities must be unique within a webhook.", previous+1, i+1, priority),
+			)
+			continue
+		}
+		priorities[priority] = i
+	}
+}
+
+func (r *WorkspaceWebhookV2Resource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema("organization_id", "workspace_id", "id")
+}
+
 func (r *WorkspaceWebhookV2Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importStateFromIdentity(ctx, req, resp) {
+		return
+	}
+
+	steps := []importStep{organizationImportStep, workspaceImportStep, {
+		attribute:  "id",
+		kind:       "Webhook",
+		collection: "organization/%s/workspace/%s/webhook",
+		filter:     "webhook",
+		entity:     new(client.WorkspaceWebhookV2Entity),
+	}}
+	api := importAPI{client: r.client, endpoint: r.endpoint, token: r.token}
+	if api.importByName(ctx, req, resp, steps...) {
+		return
+	}
+
 	idParts := strings.Split(req.ID, ",")
 
 	if len(idParts) != 3 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: 'organization_ID,workspace_ID, ID', Got: %q", req.ID),
+			fmt.Sprintf("Expected import identifier with format: 'organization_ID,workspace_ID, ID' or '%s', Got: %q", importNamesFormat(steps...), req.ID),
 		)
 		return
 	}
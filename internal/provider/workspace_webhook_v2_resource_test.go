@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
@@ -180,3 +182,487 @@ func TestWorkspaceWebhookV2Resource_Update_SendsValidJsonApiPayload(t *testing.T
 		t.Errorf("expected read-only empty fields to be omitted from the PATCH body, got: %s", patchBody)
 	}
 }
+
+func webhookV2EventValue(t *testing.T, objType tftypes.Object, overrides map[string]tftypes.Value) tftypes.Value {
+	t.Helper()
+
+	listType, ok := objType.AttributeTypes["event"].(tftypes.List)
+	if !ok {
+		t.Fatalf("expected event to be a tftypes.List")
+	}
+	eventType, ok := listType.ElementType.(tftypes.Object)
+	if !ok {
+		t.Fatalf("expected event elements to be a tftypes.Object")
+	}
+	return buildObjectValue(eventType, overrides)
+}
+
+func TestWorkspaceWebhookV2Resource_Create_SendsEventsInOneAtomicRequest(t *testing.T) {
+	ctx := context.Background()
+	s, objType := workspaceWebhookV2SchemaAndType(t, ctx)
+	listType := objType.AttributeTypes["event"]
+
+	var requests int
+	var operations []map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/operations", func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		var body struct {
+			Operations []map[string]any `json:"atomic:operations"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("decoding request body: %v", err)
+		}
+		operations = body.Operations
+		fmt.Fprint(w, `{"atomic:results":[{"data":{"type":"webhook","id":"wh-1"}},{"data":{"type":"webhook_event","id":"ev-1"}},{"data":{"type":"webhook_event","id":"ev-2"}}]}`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	r := &WorkspaceWebhookV2Resource{client: server.Client(), endpoint: server.URL, token: "test-token"}
+
+	planValue := buildObjectValue(objType, map[string]tftypes.Value{
+		"organization_id": tftypes.NewValue(tftypes.String, "org-1"),
+		"workspace_id":    tftypes.NewValue(tftypes.String, "ws-1"),
+		"migrated_v2":     tftypes.NewValue(tftypes.Bool, true),
+		"event": tftypes.NewValue(listType, []tftypes.Value{
+			webhookV2EventValue(t, objType, map[string]tftypes.Value{
+				"event":    tftypes.NewValue(tftypes.String, "PUSH"),
+				"branch":   tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "main")}),
+				"priority": tftypes.NewValue(tftypes.Number, 1),
+			}),
+			webhookV2EventValue(t, objType, map[string]tftypes.Value{
+				"event":    tftypes.NewValue(tftypes.String, "PULL_REQUEST"),
+				"priority": tftypes.NewValue(tftypes.Number, 2),
+			}),
+		}),
+	})
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: s}}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: planValue}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create returned diagnostics: %v", resp.Diagnostics)
+	}
+
+	if requests != 1 || len(operations) != 3 {
+		t.Fatalf("expected one atomic request with 3 operations, got %d requests and %d operations", requests, len(operations))
+	}
+	webhookID := operations[0]["data"].(map[string]any)["id"].(string)
+	if got := operations[1]["href"]; got != "/organization/org-1/workspace/ws-1/webhook/"+webhookID+"/events" {
+		t.Errorf("unexpected event href %v", got)
+	}
+
+	var result WorkspaceWebhookV2ResourceModel
+	if diags := resp.State.Get(ctx, &result); diags.HasError() {
+		t.Fatalf("reading resulting state: %v", diags)
+	}
+	events, diags := webhookV2Events(ctx, result.Events)
+	if diags.HasError() {
+		t.Fatalf("reading events: %v", diags)
+	}
+	if len(events) != 2 || events[0].ID.ValueString() != "ev-1" || events[1].ID.ValueString() != "ev-2" {
+		t.Errorf("unexpected events in state: %v", events)
+	}
+}
+
+func TestWebhookV2EventOperations(t *testing.T) {
+	ctx := context.Background()
+
+	event := func(id string, priority int64) webhookV2EventModel {
+		return webhookV2EventModel{
+			ID:           types.StringValue(id),
+			Event:        types.StringValue("PUSH"),
+			Branch:       types.ListNull(types.StringType),
+			Path:         types.ListNull(types.StringType),
+			Tag:          types.ListNull(types.StringType),
+			SourceBranch: types.ListNull(types.StringType),
+			Label:        types.ListNull(types.StringType),
+			Priority:     types.Int64Value(priority),
+		}
+	}
+
+	planned := []webhookV2EventModel{event("", 1)}
+	planned[0].Priority = types.Int64Unknown()
+	current := []webhookV2EventModel{event("ev-1", 3), event("ev-2", 4)}
+
+	operations, events, diags := webhookV2EventOperations(ctx, "/webhook/wh-1/events", planned, current)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if len(operations) != 3 || operations[0]["op"] != "remove" || operations[1]["op"] != "update" || operations[2]["op"] != "update" {
+		t.Fatalf("unexpected operations: %v", operations)
+	}
+	if got := operations[0]["href"]; got != "/webhook/wh-1/events/ev-2" {
+		t.Errorf("unexpected remove href %v", got)
+	}
+	if events[0].ID.ValueString() != "ev-1" || events[0].Priority.ValueInt64() != 1 {
+		t.Errorf("unexpected event %v", events[0])
+	}
+}
+
+// TestWebhookV2EventOperations_Priorities replays the operations in order
+// and checks no two events ever share a priority, as Terrakube checks after
+// every operation.
+func TestWebhookV2EventOperations_Priorities(t *testing.T) {
+	ctx := context.Background()
+
+	event := func(id, kind string, priority int64) webhookV2EventModel {
+		return webhookV2EventModel{
+			ID:           types.StringValue(id),
+			Event:        types.StringValue(kind),
+			Branch:       types.ListNull(types.StringType),
+			Path:         types.ListNull(types.StringType),
+			Tag:          types.ListNull(types.StringType),
+			SourceBranch: types.ListNull(types.StringType),
+			Label:        types.ListNull(types.StringType),
+			Priority:     types.Int64Value(priority),
+		}
+	}
+
+	tests := []struct {
+		name       string
+		current    []webhookV2EventModel
+		planned    []webhookV2EventModel
+		operations int
+		want       []string
+		priorities map[string]int64
+	}{
+		{
+			name:       "shifted",
+			current:    []webhookV2EventModel{event("ev-a", "PUSH", 1), event("ev-b", "PUSH", 2)},
+			planned:    []webhookV2EventModel{event("", "PUSH", 2), event("", "PUSH", 3)},
+			operations: 2,
+			want:       []string{"ev-b", "ev-a"},
+			priorities: map[string]int64{"ev-a": 3, "ev-b": 2},
+		},
+		{
+			name:       "all shifted",
+			current:    []webhookV2EventModel{event("ev-a", "PUSH", 1), event("ev-b", "RELEASE", 2)},
+			planned:    []webhookV2EventModel{event("", "PUSH", 2), event("", "RELEASE", 1)},
+			operations: 2,
+			want:       []string{"ev-b", "ev-a"},
+			priorities: map[string]int64{"ev-a": 1, "ev-b": 2},
+		},
+		{
+			name:       "moved to new priorities",
+			current:    []webhookV2EventModel{event("ev-a", "PUSH", 1), event("ev-b", "PUSH", 2), event("ev-c", "PUSH", 3)},
+			planned:    []webhookV2EventModel{event("", "PUSH", 4), event("", "PUSH", 5), event("", "PUSH", 6)},
+			operations: 6,
+			want:       []string{"ev-a", "ev-b", "ev-c"},
+			priorities: map[string]int64{"ev-a": 4, "ev-b": 5, "ev-c": 6},
+		},
+		{
+			name:       "inserted at the top",
+			current:    []webhookV2EventModel{event("ev-a", "PUSH", 1), event("ev-b", "RELEASE", 2)},
+			planned:    []webhookV2EventModel{event("", "PULL_REQUEST", 3), event("", "PUSH", 1), event("", "RELEASE", 2)},
+			operations: 1,
+			want:       []string{"", "ev-a", "ev-b"},
+			priorities: map[string]int64{"ev-a": 1, "ev-b": 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operations, events, diags := webhookV2EventOperations(ctx, "/webhook/wh-1/events", tt.planned, tt.current)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if len(operations) != tt.operations {
+				t.Errorf("expected %d operations, got %v", tt.operations, operations)
+			}
+
+			priorities := map[string]int64{}
+			for _, event := range tt.current {
+				priorities[event.ID.ValueString()] = event.Priority.ValueInt64()
+			}
+			for _, operation := range operations {
+				data := operation["data"].(map[string]interface{})
+				priorities[data["id"].(string)] = data["attributes"].(map[string]interface{})["priority"].(int64)
+				seen := map[int64]string{}
+				for id, priority := range priorities {
+					if other, ok := seen[priority]; ok {
+						t.Fatalf("%s and %s share priority %d after %v", id, other, priority, operation)
+					}
+					seen[priority] = id
+				}
+			}
+
+			for id, priority := range tt.priorities {
+				if priorities[id] != priority {
+					t.Errorf("expected %s at priority %d, got %d", id, priority, priorities[id])
+				}
+			}
+			for i, id := range tt.want {
+				if id != "" && events[i].ID.ValueString() != id {
+					t.Errorf("expected event %d to update %s, got %s", i, id, events[i].ID.ValueString())
+				}
+			}
+		})
+	}
+}
+
+func TestSortWebhookV2Events(t *testing.T) {
+	event := func(id string, priority int64) webhookV2EventModel {
+		return webhookV2EventModel{ID: types.StringValue(id), Priority: types.Int64Value(priority)}
+	}
+
+	events := []webhookV2EventModel{event("ev-3", 1), event("ev-1", 5), event("ev-2", 2), event("ev-4", 0)}
+	sortWebhookV2Events(events, []webhookV2EventModel{event("ev-1", 5), event("ev-2", 2)})
+
+	var ids []string
+	for _, event := range events {
+		ids = append(ids, event.ID.ValueString())
+	}
+	if got := strings.Join(ids, ","); got != "ev-1,ev-2,ev-4,ev-3" {
+		t.Errorf("unexpected order %s", got)
+	}
+}
+
+func TestWorkspaceWebhookV2Resource_ValidateConfig_DuplicatePriority(t *testing.T) {
+	ctx := context.Background()
+	s, objType := workspaceWebhookV2SchemaAndType(t, ctx)
+	listType := objType.AttributeTypes["event"]
+
+	config := buildObjectValue(objType, map[string]tftypes.Value{
+		"event": tftypes.NewValue(listType, []tftypes.Value{
+			webhookV2EventValue(t, objType, nil),
+			webhookV2EventValue(t, objType, map[string]tftypes.Value{
+				"priority": tftypes.NewValue(tftypes.Number, 1),
+			}),
+		}),
+	})
+
+	resp := &resource.ValidateConfigResponse{}
+	(&WorkspaceWebhookV2Resource{}).ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: s, Raw: config}}, resp)
+	if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Duplicate Webhook Event Priority" {
+		t.Errorf("expected a duplicate priority error, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestWorkspaceWebhookV2Resource_ValidateConfig_TagFilterOnPush(t *testing.T) {
+	ctx := context.Background()
+	s, objType := workspaceWebhookV2SchemaAndType(t, ctx)
+	listType := objType.AttributeTypes["event"]
+	stringList := tftypes.List{ElementType: tftypes.String}
+
+	config := buildObjectValue(objType, map[string]tftypes.Value{
+		"event": tftypes.NewValue(listType, []tftypes.Value{
+			webhookV2EventValue(t, objType, map[string]tftypes.Value{
+				"event": tftypes.NewValue(tftypes.String, "PUSH"),
+				"tag":   tftypes.NewValue(stringList, []tftypes.Value{tftypes.NewValue(tftypes.String, "v.*")}),
+			}),
+		}),
+	})
+
+	resp := &resource.ValidateConfigResponse{}
+	(&WorkspaceWebhookV2Resource{}).ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: s, Raw: config}}, resp)
+	if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Invalid Webhook Event Filter" {
+		t.Errorf("expected an invalid filter error, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestWorkspaceWebhookV2Resource_MoveState_FromLegacyWebhook(t *testing.T) {
+	ctx := context.Background()
+	s, _ := workspaceWebhookV2SchemaAndType(t, ctx)
+
+	var legacySchema resource.SchemaResponse
+	NewWorkspaceWebhookResource().Schema(ctx, resource.SchemaRequest{}, &legacySchema)
+	legacyType := legacySchema.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	stringList := tftypes.List{ElementType: tftypes.String}
+
+	source := tfsdk.State{Schema: legacySchema.Schema, Raw: buildObjectValue(legacyType, map[string]tftypes.Value{
+		"id":              tftypes.NewValue(tftypes.String, "wh-1"),
+		"organization_id": tftypes.NewValue(tftypes.String, "org-1"),
+		"workspace_id":    tftypes.NewValue(tftypes.String, "ws-1"),
+		"path":            tftypes.NewValue(stringList, []tftypes.Value{tftypes.NewValue(tftypes.String, "modules/.*")}),
+		"branch":          tftypes.NewValue(stringList, []tftypes.Value{tftypes.NewValue(tftypes.String, "main")}),
+		"template_id":     tftypes.NewValue(tftypes.String, "tpl-1"),
+		"remote_hook_id":  tftypes.NewValue(tftypes.String, "remote-1"),
+		"event":           tftypes.NewValue(tftypes.String, "PUSH"),
+	})}
+
+	r := &WorkspaceWebhookV2Resource{}
+	movers := r.MoveState(ctx)
+	if len(movers) != 1 || movers[0].SourceSchema == nil {
+		t.Fatalf("expected a single state mover with the legacy schema")
+	}
+
+	resp := &resource.MoveStateResponse{TargetState: tfsdk.State{Schema: s}}
+	movers[0].StateMover(ctx, resource.MoveStateRequest{SourceTypeName: "terrakube_workspace_webhook", SourceState: &source}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("MoveState returned diagnostics: %v", resp.Diagnostics)
+	}
+
+	var moved WorkspaceWebhookV2ResourceModel
+	if diags := resp.TargetState.Get(ctx, &moved); diags.HasError() {
+		t.Fatalf("reading moved state: %v", diags)
+	}
+	if moved.ID.ValueString() != "wh-1" || moved.RemoteHookId.ValueString() != "remote-1" {
+		t.Errorf("unexpected moved webhook %v", moved)
+	}
+	events, diags := webhookV2Events(ctx, moved.Events)
+	if diags.HasError() {
+		t.Fatalf("reading events: %v", diags)
+	}
+	if len(events) != 1 || !webhookV2EventPending(events[0]) || events[0].TemplateId.ValueString() != "tpl-1" || events[0].Priority.ValueInt64() != 1 {
+		t.Errorf("expected a single pending event built from the legacy webhook, got %v", events)
+	}
+
+	other := &resource.MoveStateResponse{TargetState: tfsdk.State{Schema: s}}
+	movers[0].StateMover(ctx, resource.MoveStateRequest{SourceTypeName: "terrakube_workspace_cli", SourceState: &source}, other)
+	if other.Diagnostics.HasError() || !other.TargetState.Raw.IsNull() {
+		t.Errorf("expected other resource types to be skipped")
+	}
+}
+
+func TestWebhookV2EventOperations_AddsPendingEvents(t *testing.T) {
+	ctx := context.Background()
+
+	pending := webhookV2EventModel{
+		ID:       types.StringNull(),
+		Event:    types.StringValue("PUSH"),
+		Branch:   types.ListNull(types.StringType),
+		Path:     types.ListNull(types.StringType),
+		Priority: types.Int64Value(1),
+	}
+
+	operations, events, diags := webhookV2EventOperations(ctx, "/webhook/wh-1/events", []webhookV2EventModel{pending}, []webhookV2EventModel{pending})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(operations) != 1 || operations[0]["op"] != "add" {
+		t.Fatalf("expected the pending event to be added, got %v", operations)
+	}
+	if webhookV2EventPending(events[0]) {
+		t.Errorf("expected the added event to get an id")
+	}
+}
+
+func TestWorkspaceWebhookV2Resource_ModifyPlan_MigratesPendingWebhook(t *testing.T) {
+	ctx := context.Background()
+	s, objType := workspaceWebhookV2SchemaAndType(t, ctx)
+	listType := objType.AttributeTypes["event"]
+
+	event := func(id tftypes.Value) tftypes.Value {
+		return webhookV2EventValue(t, objType, map[string]tftypes.Value{
+			"id":                  id,
+			"event":               tftypes.NewValue(tftypes.String, "PUSH"),
+			"priority":            tftypes.NewValue(tftypes.Number, 1),
+			"pr_workflow_enabled": tftypes.NewValue(tftypes.Bool, false),
+			"pr_apply_enabled":    tftypes.NewValue(tftypes.Bool, false),
+		})
+	}
+	base := map[string]tftypes.Value{
+		"id":              tftypes.NewValue(tftypes.String, "wh-1"),
+		"organization_id": tftypes.NewValue(tftypes.String, "org-1"),
+		"workspace_id":    tftypes.NewValue(tftypes.String, "ws-1"),
+		"remote_hook_id":  tftypes.NewValue(tftypes.String, "remote-1"),
+		"migrated_v2":     tftypes.NewValue(tftypes.Bool, false),
+	}
+	with := func(events ...tftypes.Value) tftypes.Value {
+		values := map[string]tftypes.Value{"event": tftypes.NewValue(listType, events)}
+		for k, v := range base {
+			values[k] = v
+		}
+		return buildObjectValue(objType, values)
+	}
+
+	config := buildObjectValue(objType, map[string]tftypes.Value{
+		"organization_id": tftypes.NewValue(tftypes.String, "org-1"),
+		"workspace_id":    tftypes.NewValue(tftypes.String, "ws-1"),
+		"event":           tftypes.NewValue(listType, []tftypes.Value{event(tftypes.NewValue(tftypes.String, nil))}),
+	})
+	req := resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: s, Raw: config},
+		State:  tfsdk.State{Schema: s, Raw: with(event(tftypes.NewValue(tftypes.String, nil)))},
+		Plan:   tfsdk.Plan{Schema: s, Raw: with(event(tftypes.NewValue(tftypes.String, tftypes.UnknownValue)))},
+	}
+	resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+	(&WorkspaceWebhookV2Resource{}).ModifyPlan(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("ModifyPlan returned diagnostics: %v", resp.Diagnostics)
+	}
+
+	var plan WorkspaceWebhookV2ResourceModel
+	if diags := resp.Plan.Get(ctx, &plan); diags.HasError() {
+		t.Fatalf("reading plan: %v", diags)
+	}
+	if !plan.MigratedV2.ValueBool() {
+		t.Errorf("expected migrated_v2 to be planned to true for a moved webhook")
+	}
+
+	noEvents := buildObjectValue(objType, map[string]tftypes.Value{
+		"organization_id": tftypes.NewValue(tftypes.String, "org-1"),
+		"workspace_id":    tftypes.NewValue(tftypes.String, "ws-1"),
+		"event":           tftypes.NewValue(listType, []tftypes.Value{}),
+	})
+	req.Config = tfsdk.Config{Schema: s, Raw: noEvents}
+	req.Plan = tfsdk.Plan{Schema: s, Raw: with()}
+	resp = &resource.ModifyPlanResponse{Plan: req.Plan}
+	(&WorkspaceWebhookV2Resource{}).ModifyPlan(ctx, req, resp)
+	if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Missing Webhook Event" {
+		t.Errorf("expected a moved webhook without event blocks to ask for one, got %v", resp.Diagnostics)
+	}
+}
+
+func TestWorkspaceWebhookV2Resource_ModifyPlan_PairsEventsByPriority(t *testing.T) {
+	ctx := context.Background()
+	s, objType := workspaceWebhookV2SchemaAndType(t, ctx)
+	listType := objType.AttributeTypes["event"]
+
+	event := func(id tftypes.Value, kind string, priority int) tftypes.Value {
+		return webhookV2EventValue(t, objType, map[string]tftypes.Value{
+			"id":                  id,
+			"event":               tftypes.NewValue(tftypes.String, kind),
+			"priority":            tftypes.NewValue(tftypes.Number, priority),
+			"pr_workflow_enabled": tftypes.NewValue(tftypes.Bool, false),
+			"pr_apply_enabled":    tftypes.NewValue(tftypes.Bool, false),
+		})
+	}
+	with := func(events ...tftypes.Value) tftypes.Value {
+		return buildObjectValue(objType, map[string]tftypes.Value{
+			"id":              tftypes.NewValue(tftypes.String, "wh-1"),
+			"organization_id": tftypes.NewValue(tftypes.String, "org-1"),
+			"workspace_id":    tftypes.NewValue(tftypes.String, "ws-1"),
+			"remote_hook_id":  tftypes.NewValue(tftypes.String, "wh-1"),
+			"migrated_v2":     tftypes.NewValue(tftypes.Bool, true),
+			"event":           tftypes.NewValue(listType, events),
+		})
+	}
+	unknown := tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+
+	state := with(
+		event(tftypes.NewValue(tftypes.String, "ev-1"), "PUSH", 1),
+		event(tftypes.NewValue(tftypes.String, "ev-2"), "RELEASE", 2),
+	)
+	// The id planned at the top belonged to the event now planned second.
+	planned := with(
+		event(tftypes.NewValue(tftypes.String, "ev-1"), "PULL_REQUEST", 3),
+		event(unknown, "PUSH", 1),
+		event(unknown, "RELEASE", 2),
+	)
+	req := resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: s, Raw: planned},
+		State:  tfsdk.State{Schema: s, Raw: state},
+		Plan:   tfsdk.Plan{Schema: s, Raw: planned},
+	}
+	resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+	(&WorkspaceWebhookV2Resource{}).ModifyPlan(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("ModifyPlan returned diagnostics: %v", resp.Diagnostics)
+	}
+
+	var plan WorkspaceWebhookV2ResourceModel
+	if diags := resp.Plan.Get(ctx, &plan); diags.HasError() {
+		t.Fatalf("reading plan: %v", diags)
+	}
+	events, diags := webhookV2Events(ctx, plan.Events)
+	if diags.HasError() {
+		t.Fatalf("reading events: %v", diags)
+	}
+	if !events[0].ID.IsUnknown() || events[1].ID.ValueString() != "ev-1" || events[2].ID.ValueString() != "ev-2" {
+		t.Errorf("expected the inserted event to be created and the others kept, got %v", events)
+	}
+}
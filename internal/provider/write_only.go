@@ -0,0 +1,24 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// secretValue returns the secret to send to the API for an argument that can
+// be configured either as a regular attribute or as its write-only "_wo"
+// variant. Write-only values are always null in the plan and state, so they
+// have to be read from the configuration on every apply.
+func secretValue(ctx context.Context, config tfsdk.Config, planned types.String, writeOnlyAttribute string) (types.String, diag.Diagnostics) {
+	if !planned.IsNull() {
+		return planned, nil
+	}
+
+	var writeOnly types.String
+	diags := config.GetAttribute(ctx, path.Root(writeOnlyAttribute), &writeOnly)
+	return writeOnly, diags
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestWriteOnlyAttributes_Schema(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		resource resource.Resource
+		pairs    map[string]string
+	}{
+		"workspace_variable":    {&WorkspaceVariableResource{}, map[string]string{"value_wo": "value_wo_version"}},
+		"organization_variable": {&OrganizationVariableResource{}, map[string]string{"value_wo": "value_wo_version"}},
+		"collection_item":       {&CollectionItemResource{}, map[string]string{"value_wo": "value_wo_version"}},
+		"ssh":                   {&SshResource{}, map[string]string{"private_key_wo": "private_key_wo_version"}},
+		"vcs":                   {&VcsResource{}, map[string]string{"client_secret_wo": "client_secret_wo_version", "private_key_wo": "private_key_wo_version"}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var schemaResp resource.SchemaResponse
+			tt.resource.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+			if schemaResp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics building schema: %v", schemaResp.Diagnostics)
+			}
+
+			for woName, versionName := range tt.pairs {
+				wo, ok := schemaResp.Schema.Attributes[woName].(schema.StringAttribute)
+				if !ok {
+					t.Fatalf("expected %q to be a StringAttribute", woName)
+				}
+				if !wo.WriteOnly || !wo.Sensitive || !wo.Optional || wo.Computed {
+					t.Errorf("%q: expected an optional, sensitive, write-only, non-computed attribute, got %+v", woName, wo)
+				}
+
+				regular := strings.TrimSuffix(woName, "_wo")
+				if attr, ok := schemaResp.Schema.Attributes[regular]; !ok || attr.IsRequired() {
+					t.Errorf("%q: expected the regular attribute to exist and be optional so %q can replace it", regular, woName)
+				}
+
+				if _, ok := schemaResp.Schema.Attributes[versionName].(schema.Int64Attribute); !ok {
+					t.Errorf("expected %q to be an Int64Attribute", versionName)
+				}
+			}
+		})
+	}
+}
+
+func TestSecretValue(t *testing.T) {
+	ctx := context.Background()
+
+	r := &WorkspaceVariableResource{}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("expected schema type to be a tftypes.Object")
+	}
+
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw: buildObjectValue(objType, map[string]tftypes.Value{
+			"value_wo": tftypes.NewValue(tftypes.String, "from-config"),
+		}),
+	}
+
+	got, diags := secretValue(ctx, config, types.StringNull(), "value_wo")
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if got.ValueString() != "from-config" {
+		t.Errorf("expected the write-only value from config, got %s", got)
+	}
+
+	got, diags = secretValue(ctx, config, types.StringValue("from-plan"), "value_wo")
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if got.ValueString() != "from-plan" {
+		t.Errorf("expected the planned value to take precedence, got %s", got)
+	}
+}
@@ -0,0 +1,390 @@
+// Package quartz parses cron expressions in the Quartz scheduler notation used
+// by Terrakube workspace schedules and computes when they fire.
+//
+// An expression has six or seven space separated fields:
+//
+//	seconds minutes hours day-of-month month day-of-week [year]
+//
+// Exactly one of day-of-month and day-of-week must be '?'. Besides '*', ',',
+// '-' and '/', day-of-month accepts 'L', 'L-n', 'LW' and 'nW', and day-of-week
+// accepts 'L', 'nL' and 'n#k'. Month and day-of-week also accept names
+// (JAN-DEC, SUN-SAT), with day-of-week numbered 1 (SUN) to 7 (SAT).
+package quartz
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	minYear = 1970
+	maxYear = 2099
+)
+
+// ParseError describes why a single field of an expression is invalid.
+type ParseError struct {
+	Field  string
+	Value  string
+	Reason string
+}
+
+func (e *ParseError) Error() string {
+	if e.Field == "" {
+		return e.Reason
+	}
+	return fmt.Sprintf("invalid %s field %q: %s", e.Field, e.Value, e.Reason)
+}
+
+type fieldSpec struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+	wraps bool
+}
+
+var (
+	secondsField    = fieldSpec{name: "seconds", min: 0, max: 59, wraps: true}
+	minutesField    = fieldSpec{name: "minutes", min: 0, max: 59, wraps: true}
+	hoursField      = fieldSpec{name: "hours", min: 0, max: 23, wraps: true}
+	dayOfMonthField = fieldSpec{name: "day-of-month", min: 1, max: 31, wraps: true}
+	monthField      = fieldSpec{name: "month", min: 1, max: 12, wraps: true, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}}
+	dayOfWeekField = fieldSpec{name: "day-of-week", min: 1, max: 7, wraps: true, names: map[string]int{
+		"SUN": 1, "MON": 2, "TUE": 3, "WED": 4, "THU": 5, "FRI": 6, "SAT": 7,
+	}}
+	yearField = fieldSpec{name: "year", min: minYear, max: maxYear}
+)
+
+type dayOfMonthSpec struct {
+	any            bool
+	days           []bool
+	lastDay        bool
+	lastOffset     int
+	lastWeekday    bool
+	nearestWeekday int
+}
+
+type dayOfWeekSpec struct {
+	any     bool
+	days    []bool
+	last    int
+	nthDay  int
+	nthWeek int
+}
+
+// Expression is a parsed Quartz cron expression.
+type Expression struct {
+	seconds    []bool
+	minutes    []bool
+	hours      []bool
+	dayOfMonth dayOfMonthSpec
+	months     []bool
+	dayOfWeek  dayOfWeekSpec
+	years      []bool
+}
+
+// Parse parses a Quartz cron expression. Names and special characters are
+// case-insensitive.
+func Parse(expr string) (*Expression, error) {
+	fields := strings.Fields(strings.ToUpper(expr))
+	if len(fields) < 6 || len(fields) > 7 {
+		return nil, &ParseError{Reason: fmt.Sprintf("expected 6 or 7 fields (seconds minutes hours day-of-month month day-of-week [year]), got %d", len(fields))}
+	}
+
+	var e Expression
+	var err error
+
+	if e.seconds, err = parseList(fields[0], secondsField); err != nil {
+		return nil, err
+	}
+	if e.minutes, err = parseList(fields[1], minutesField); err != nil {
+		return nil, err
+	}
+	if e.hours, err = parseList(fields[2], hoursField); err != nil {
+		return nil, err
+	}
+	if e.dayOfMonth, err = parseDayOfMonth(fields[3]); err != nil {
+		return nil, err
+	}
+	if e.months, err = parseList(fields[4], monthField); err != nil {
+		return nil, err
+	}
+	if e.dayOfWeek, err = parseDayOfWeek(fields[5]); err != nil {
+		return nil, err
+	}
+	if len(fields) == 7 && fields[6] != "*" {
+		if e.years, err = parseList(fields[6], yearField); err != nil {
+			return nil, err
+		}
+	}
+
+	if e.dayOfMonth.any == e.dayOfWeek.any {
+		return nil, &ParseError{Reason: "exactly one of the day-of-month and day-of-week fields must be '?'"}
+	}
+
+	return &e, nil
+}
+
+// Next returns the first fire time strictly after the given time, in the
+// location of after. It returns the zero time when the expression never fires
+// again.
+func (e *Expression) Next(after time.Time) time.Time {
+	loc := after.Location()
+	start := after.Truncate(time.Second).Add(time.Second)
+	startClock := start.Hour()*3600 + start.Minute()*60 + start.Second()
+
+	// Walk calendar days at noon UTC so DST transitions don't skip or repeat a day.
+	y, m, d := start.Date()
+	day := time.Date(y, m, d, 12, 0, 0, 0, time.UTC)
+	for first := true; day.Year() <= maxYear; day, first = day.AddDate(0, 0, 1), false {
+		if !e.matchesDay(day) {
+			continue
+		}
+
+		y, m, d := day.Date()
+		for h := range e.hours {
+			if !e.hours[h] {
+				continue
+			}
+			for mi := range e.minutes {
+				if !e.minutes[mi] {
+					continue
+				}
+				for s := range e.seconds {
+					if !e.seconds[s] || (first && h*3600+mi*60+s < startClock) {
+						continue
+					}
+
+					candidate := time.Date(y, m, d, h, mi, s, 0, loc)
+					// Local times skipped by a DST transition never fire.
+					if candidate.Hour() != h || candidate.Minute() != mi || candidate.Before(start) {
+						continue
+					}
+					return candidate
+				}
+			}
+		}
+	}
+
+	return time.Time{}
+}
+
+// NextN returns up to n fire times after the given time.
+func (e *Expression) NextN(after time.Time, n int) []time.Time {
+	runs := make([]time.Time, 0, n)
+	for len(runs) < n {
+		next := e.Next(after)
+		if next.IsZero() {
+			break
+		}
+		runs = append(runs, next)
+		after = next
+	}
+	return runs
+}
+
+func (e *Expression) matchesDay(day time.Time) bool {
+	y, m, d := day.Date()
+
+	if y < minYear || (e.years != nil && !e.years[y]) || !e.months[m] {
+		return false
+	}
+
+	lastDay := time.Date(y, m+1, 0, 12, 0, 0, 0, time.UTC).Day()
+
+	if !e.dayOfMonth.any {
+		dom := e.dayOfMonth
+		switch {
+		case dom.lastDay:
+			return d == lastDay-dom.lastOffset
+		case dom.lastWeekday:
+			return d == nearestWeekday(lastDay, lastDay, y, m)
+		case dom.nearestWeekday > 0:
+			return dom.nearestWeekday <= lastDay && d == nearestWeekday(dom.nearestWeekday, lastDay, y, m)
+		default:
+			return dom.days[d]
+		}
+	}
+
+	dow := e.dayOfWeek
+	weekday := int(day.Weekday()) + 1
+	switch {
+	case dow.last > 0:
+		return weekday == dow.last && d+7 > lastDay
+	case dow.nthWeek > 0:
+		return weekday == dow.nthDay && (d-1)/7+1 == dow.nthWeek
+	default:
+		return dow.days[weekday]
+	}
+}
+
+// nearestWeekday returns the weekday closest to the target day of the month
+// without crossing into another month.
+func nearestWeekday(target, lastDay, y int, m time.Month) int {
+	switch time.Date(y, m, target, 12, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if target == 1 {
+			return target + 2
+		}
+		return target - 1
+	case time.Sunday:
+		if target == lastDay {
+			return target - 2
+		}
+		return target + 1
+	default:
+		return target
+	}
+}
+
+func parseDayOfMonth(field string) (dayOfMonthSpec, error) {
+	var spec dayOfMonthSpec
+
+	switch {
+	case field == "?":
+		spec.any = true
+	case field == "L":
+		spec.lastDay = true
+	case strings.HasPrefix(field, "L-"):
+		offset, err := strconv.Atoi(field[2:])
+		if err != nil || offset < 0 || offset > 30 {
+			return spec, &ParseError{Field: dayOfMonthField.name, Value: field, Reason: "offset from the last day must be between 0 and 30"}
+		}
+		spec.lastDay = true
+		spec.lastOffset = offset
+	case field == "LW":
+		spec.lastWeekday = true
+	case strings.HasSuffix(field, "W"):
+		day, err := strconv.Atoi(strings.TrimSuffix(field, "W"))
+		if err != nil || day < dayOfMonthField.min || day > dayOfMonthField.max {
+			return spec, &ParseError{Field: dayOfMonthField.name, Value: field, Reason: "'W' must follow a single day between 1 and 31"}
+		}
+		spec.nearestWeekday = day
+	default:
+		days, err := parseList(field, dayOfMonthField)
+		if err != nil {
+			return spec, err
+		}
+		spec.days = days
+	}
+
+	return spec, nil
+}
+
+func parseDayOfWeek(field string) (dayOfWeekSpec, error) {
+	var spec dayOfWeekSpec
+
+	switch {
+	case field == "?":
+		spec.any = true
+	case field == "L":
+		spec.days = make([]bool, dayOfWeekField.max+1)
+		spec.days[dayOfWeekField.names["SAT"]] = true
+	case strings.HasSuffix(field, "L"):
+		day, err := parseValue(strings.TrimSuffix(field, "L"), dayOfWeekField)
+		if err != nil {
+			return spec, &ParseError{Field: dayOfWeekField.name, Value: field, Reason: "'L' must follow a single day of the week"}
+		}
+		spec.last = day
+	case strings.Contains(field, "#"):
+		parts := strings.SplitN(field, "#", 2)
+		day, err := parseValue(parts[0], dayOfWeekField)
+		if err != nil {
+			return spec, &ParseError{Field: dayOfWeekField.name, Value: field, Reason: "'#' must follow a single day of the week"}
+		}
+		week, err := strconv.Atoi(parts[1])
+		if err != nil || week < 1 || week > 5 {
+			return spec, &ParseError{Field: dayOfWeekField.name, Value: field, Reason: "the occurrence after '#' must be between 1 and 5"}
+		}
+		spec.nthDay = day
+		spec.nthWeek = week
+	default:
+		days, err := parseList(field, dayOfWeekField)
+		if err != nil {
+			return spec, err
+		}
+		spec.days = days
+	}
+
+	return spec, nil
+}
+
+// parseList parses a comma separated list of values, ranges and increments.
+// Ranges whose start is after their end wrap around when the field allows it,
+// e.g. FRI-MON or 22-2.
+func parseList(field string, spec fieldSpec) ([]bool, error) {
+	set := make([]bool, spec.max+1)
+	span := spec.max - spec.min + 1
+
+	for _, part := range strings.Split(field, ",") {
+		if part == "" {
+			return nil, &ParseError{Field: spec.name, Value: field, Reason: "empty list element"}
+		}
+
+		rangePart, step := part, 1
+		hasStep := false
+		if i := strings.Index(part, "/"); i >= 0 {
+			rangePart = part[:i]
+			hasStep = true
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step < 1 || step > spec.max {
+				return nil, &ParseError{Field: spec.name, Value: field, Reason: fmt.Sprintf("increment %q must be between 1 and %d", part[i+1:], spec.max)}
+			}
+		}
+
+		var lo, hi int
+		switch {
+		case rangePart == "*":
+			lo, hi = spec.min, spec.max
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = parseValue(bounds[0], spec); err != nil {
+				return nil, &ParseError{Field: spec.name, Value: field, Reason: err.Error()}
+			}
+			if hi, err = parseValue(bounds[1], spec); err != nil {
+				return nil, &ParseError{Field: spec.name, Value: field, Reason: err.Error()}
+			}
+			if lo > hi && !spec.wraps {
+				return nil, &ParseError{Field: spec.name, Value: field, Reason: fmt.Sprintf("range start %d is after its end %d", lo, hi)}
+			}
+		default:
+			var err error
+			if lo, err = parseValue(rangePart, spec); err != nil {
+				return nil, &ParseError{Field: spec.name, Value: field, Reason: err.Error()}
+			}
+			hi = lo
+			if hasStep {
+				hi = spec.max
+			}
+		}
+
+		length := (hi-lo+span)%span + 1
+		for k := 0; k < length; k += step {
+			set[spec.min+(lo-spec.min+k)%span] = true
+		}
+	}
+
+	return set, nil
+}
+
+func parseValue(value string, spec fieldSpec) (int, error) {
+	if n, ok := spec.names[value]; ok {
+		return n, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid %s value", value, spec.name)
+	}
+	if n < spec.min || n > spec.max {
+		return 0, fmt.Errorf("%d is out of range, must be between %d and %d", n, spec.min, spec.max)
+	}
+	return n, nil
+}
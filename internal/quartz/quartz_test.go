@@ -0,0 +1,141 @@
+package quartz
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse_Valid(t *testing.T) {
+	for _, expr := range []string{
+		"0 0 3 * * ?",
+		"0 0/15 * * * ?",
+		"0 0 12 ? * MON-FRI",
+		"0 30 22 ? * fri-mon",
+		"0 0 0 L * ?",
+		"0 0 0 L-2 * ?",
+		"0 0 0 LW * ?",
+		"0 0 0 15W * ?",
+		"0 0 0 ? * 6L",
+		"0 0 0 ? * MON#2",
+		"0 0 0 1,15 JAN,JUL ? 2027-2030",
+		"0 0 0 1 1 ? *",
+		"*/10 * 22-2 * * ?",
+	} {
+		if _, err := Parse(expr); err != nil {
+			t.Errorf("Parse(%q) returned an unexpected error: %s", expr, err)
+		}
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"0 0 3 * *":              "",
+		"0 0 3 * * ? 2030 extra": "",
+		"60 0 3 * * ?":           "seconds",
+		"0 0 24 * * ?":           "hours",
+		"0 0 3 32 * ?":           "day-of-month",
+		"0 0 3 * 13 ?":           "month",
+		"0 0 3 ? * 8":            "day-of-week",
+		"0 0 3 ? * MON#6":        "day-of-week",
+		"0 0 3 * FOO ?":          "month",
+		"0 0/0 3 * * ?":          "minutes",
+		"0 0 3 1,,2 * ?":         "day-of-month",
+		"0 0 3 * * MON":          "",
+		"0 0 3 ? * ?":            "",
+		"0 0 3 * * ? 2030-2027":  "year",
+		"0 0 3 * * ? 1969":       "year",
+		"0 0 ? * * ?":            "hours",
+	}
+
+	for expr, field := range tests {
+		_, err := Parse(expr)
+		if err == nil {
+			t.Errorf("Parse(%q) expected an error", expr)
+			continue
+		}
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("Parse(%q) expected a *ParseError, got %T", expr, err)
+			continue
+		}
+		if parseErr.Field != field {
+			t.Errorf("Parse(%q) reported field %q, want %q (%s)", expr, parseErr.Field, field, err)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	// Sunday, 1 March 2026.
+	after := time.Date(2026, 3, 1, 10, 20, 30, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"0 0 3 * * ?", time.Date(2026, 3, 2, 3, 0, 0, 0, time.UTC)},
+		{"0 0/15 * * * ?", time.Date(2026, 3, 1, 10, 30, 0, 0, time.UTC)},
+		{"* * * * * ?", time.Date(2026, 3, 1, 10, 20, 31, 0, time.UTC)},
+		{"0 0 12 ? * MON-FRI", time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)},
+		{"0 0 0 L * ?", time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)},
+		{"0 0 0 L-3 * ?", time.Date(2026, 3, 28, 0, 0, 0, 0, time.UTC)},
+		// 31 May 2026 is a Sunday, so the last weekday is Friday the 29th.
+		{"0 0 0 LW 5 ?", time.Date(2026, 5, 29, 0, 0, 0, 0, time.UTC)},
+		// 1 August 2026 is a Saturday, the nearest weekday without leaving the month is Monday the 3rd.
+		{"0 0 0 1W 8 ?", time.Date(2026, 8, 3, 0, 0, 0, 0, time.UTC)},
+		{"0 0 0 ? * 6L", time.Date(2026, 3, 27, 0, 0, 0, 0, time.UTC)},
+		{"0 0 0 ? * MON#2", time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)},
+		{"0 0 0 29 2 ? 2027-2099", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 0 30 2 ?", time.Time{}},
+		{"0 0 0 1 1 ? 2020", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		e, err := Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q): %s", tt.expr, err)
+		}
+		if got := e.Next(after); !got.Equal(tt.want) {
+			t.Errorf("Next(%q) = %s, want %s", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestNext_TimeZone(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database not available: %s", err)
+	}
+
+	e, err := Parse("0 30 2 * * ?")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 02:30 doesn't exist on 8 March 2026 when clocks spring forward.
+	got := e.NextN(time.Date(2026, 3, 7, 12, 0, 0, 0, loc), 2)
+	want := []time.Time{
+		time.Date(2026, 3, 9, 2, 30, 0, 0, loc),
+		time.Date(2026, 3, 10, 2, 30, 0, 0, loc),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("NextN returned %d runs, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("run %d = %s, want %s", i, got[i], want[i])
+		}
+	}
+}
+
+func TestNextN_StopsWhenExhausted(t *testing.T) {
+	e, err := Parse("0 0 0 1 1 ? 2027-2028")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := e.NextN(time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), 5)
+	if len(got) != 2 {
+		t.Errorf("expected the two remaining runs, got %v", got)
+	}
+}
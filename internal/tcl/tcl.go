@@ -0,0 +1,358 @@
+// Package tcl parses and validates Terrakube Configuration Language templates,
+// the YAML documents stored as the tcl of an organization template.
+//
+//	flow:
+//	  - type: "terraformPlan"
+//	    name: "Plan"
+//	    step: 100
+//	    commands:
+//	      - runtime: "BASH"
+//	        priority: 100
+//	        after: true
+//	        script: |
+//	          echo "plan finished"
+//	  - type: "approval"
+//	    name: "Approve"
+//	    step: 150
+//	    team: "TERRAFORM_ADVANCED"
+package tcl
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FlowTypes lists the flow types a template step can use.
+var FlowTypes = []string{
+	"terraformPlan",
+	"terraformPlanDestroy",
+	"terraformApply",
+	"terraformDestroy",
+	"customScripts",
+	"approval",
+	"disableWorkspace",
+	"scheduleTemplates",
+}
+
+// Runtimes lists the runtimes a command can use.
+var Runtimes = []string{"BASH", "GROOVY"}
+
+var syntaxErrorLine = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+var knownTemplateKeys = []string{"flow"}
+
+var knownFlowKeys = []string{"type", "name", "step", "commands", "team", "inputsEnv", "inputsTerraform", "importComands", "ignoreError", "templates"}
+
+var knownCommandKeys = []string{"runtime", "priority", "before", "after", "script", "verbose", "importComands"}
+
+// Template is a parsed template.
+type Template struct {
+	Flow []Flow `yaml:"flow"`
+}
+
+// Flow is a single step of a template.
+type Flow struct {
+	Type     string    `yaml:"type"`
+	Name     string    `yaml:"name,omitempty"`
+	Step     int       `yaml:"step"`
+	Team     string    `yaml:"team,omitempty"`
+	Commands []Command `yaml:"commands,omitempty"`
+}
+
+// Command is a script run before or after a flow step.
+type Command struct {
+	Runtime  string `yaml:"runtime"`
+	Priority int    `yaml:"priority"`
+	Before   bool   `yaml:"before,omitempty"`
+	After    bool   `yaml:"after,omitempty"`
+	Script   string `yaml:"script,omitempty"`
+}
+
+// Problem is an issue found at a position of the template. Warnings don't
+// prevent the template from running.
+type Problem struct {
+	Line    int
+	Column  int
+	Message string
+	Warning bool
+}
+
+func (p Problem) Error() string {
+	if p.Line == 0 {
+		return p.Message
+	}
+	if p.Column == 0 {
+		return fmt.Sprintf("line %d: %s", p.Line, p.Message)
+	}
+	return fmt.Sprintf("line %d, column %d: %s", p.Line, p.Column, p.Message)
+}
+
+// Parse parses and validates a template. The template is nil when any of the
+// returned problems is an error.
+func Parse(content string) (*Template, []Problem) {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &document); err != nil {
+		if match := syntaxErrorLine.FindStringSubmatch(err.Error()); match != nil {
+			line, _ := strconv.Atoi(match[1])
+			return nil, []Problem{{Line: line, Message: match[2]}}
+		}
+		return nil, []Problem{{Message: strings.TrimPrefix(err.Error(), "yaml: ")}}
+	}
+
+	v := &validation{}
+	if len(document.Content) == 0 {
+		v.errorf(&document, "the template is empty, it must define a flow")
+		return nil, v.problems
+	}
+
+	root := document.Content[0]
+	v.template(root)
+	if v.failed() {
+		return nil, v.problems
+	}
+
+	var template Template
+	if err := root.Decode(&template); err != nil {
+		v.errorf(root, "%s", err)
+		return nil, v.problems
+	}
+	return &template, v.problems
+}
+
+// Steps returns the flow steps in the order they run.
+func (t *Template) Steps() []Flow {
+	steps := append([]Flow(nil), t.Flow...)
+	sort.SliceStable(steps, func(i, j int) bool { return steps[i].Step < steps[j].Step })
+	return steps
+}
+
+// Render writes the template as canonical YAML.
+func Render(t *Template) (string, error) {
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(t); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// Equivalent reports whether two documents hold the same data once parsed as
+// YAML, regardless of formatting, quoting, comments or key order. Documents
+// that don't parse are only equivalent when they are identical.
+func Equivalent(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	var left, right any
+	if err := yaml.Unmarshal([]byte(a), &left); err != nil {
+		return false
+	}
+	if err := yaml.Unmarshal([]byte(b), &right); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(left, right)
+}
+
+type validation struct {
+	problems []Problem
+}
+
+func (v *validation) errorf(node *yaml.Node, format string, args ...any) {
+	v.problems = append(v.problems, Problem{Line: node.Line, Column: node.Column, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validation) warnf(node *yaml.Node, format string, args ...any) {
+	v.problems = append(v.problems, Problem{Line: node.Line, Column: node.Column, Message: fmt.Sprintf(format, args...), Warning: true})
+}
+
+func (v *validation) failed() bool {
+	for _, problem := range v.problems {
+		if !problem.Warning {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *validation) template(root *yaml.Node) {
+	if root.Kind != yaml.MappingNode {
+		v.errorf(root, "the template must be a mapping with a flow key")
+		return
+	}
+
+	fields := v.mapping(root, "template", knownTemplateKeys)
+	flow, ok := fields["flow"]
+	if !ok {
+		v.errorf(root, "the template must define a flow")
+		return
+	}
+	if flow.value.Kind != yaml.SequenceNode || len(flow.value.Content) == 0 {
+		v.errorf(flow.value, "flow must be a non-empty list of steps")
+		return
+	}
+
+	steps := map[int]*yaml.Node{}
+	for _, item := range flow.value.Content {
+		v.flow(item, steps)
+	}
+}
+
+func (v *validation) flow(node *yaml.Node, steps map[int]*yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		v.errorf(node, "each flow item must be a mapping")
+		return
+	}
+
+	fields := v.mapping(node, "flow", knownFlowKeys)
+
+	flowType := ""
+	if field, ok := fields["type"]; !ok {
+		v.errorf(node, "flow item is missing the type")
+	} else if flowType, ok = v.scalar(field, "type"); ok && !contains(FlowTypes, flowType) {
+		v.errorf(field.value, "unknown flow type %q, expected one of %s", flowType, strings.Join(FlowTypes, ", "))
+	}
+
+	if field, ok := fields["name"]; ok {
+		v.scalar(field, "name")
+	}
+
+	if field, ok := fields["step"]; !ok {
+		v.errorf(node, "flow item is missing the step number")
+	} else if step, ok := v.wholeNumber(field, "step", 1); ok {
+		if previous, duplicated := steps[step]; duplicated {
+			v.errorf(field.value, "step %d is already used by the flow item at line %d", step, previous.Line)
+		} else {
+			steps[step] = field.value
+		}
+	}
+
+	if field, ok := fields["team"]; ok {
+		v.scalar(field, "team")
+	} else if flowType == "approval" {
+		v.errorf(node, "approval steps must define the team that approves them")
+	}
+
+	commands, hasCommands := fields["commands"]
+	if hasCommands {
+		if commands.value.Kind != yaml.SequenceNode {
+			v.errorf(commands.value, "commands must be a list")
+		} else {
+			for _, command := range commands.value.Content {
+				v.command(command)
+			}
+		}
+	}
+	if flowType == "customScripts" && (!hasCommands || len(commands.value.Content) == 0) {
+		v.errorf(node, "customScripts steps must define at least one command")
+	}
+}
+
+func (v *validation) command(node *yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		v.errorf(node, "each command must be a mapping")
+		return
+	}
+
+	fields := v.mapping(node, "command", knownCommandKeys)
+
+	if field, ok := fields["runtime"]; !ok {
+		v.errorf(node, "command is missing the runtime")
+	} else if runtime, ok := v.scalar(field, "runtime"); ok && !contains(Runtimes, runtime) {
+		v.errorf(field.value, "unknown runtime %q, expected one of %s", runtime, strings.Join(Runtimes, ", "))
+	}
+
+	if field, ok := fields["priority"]; !ok {
+		v.errorf(node, "command is missing the priority")
+	} else {
+		v.wholeNumber(field, "priority", 0)
+	}
+
+	for _, key := range []string{"before", "after", "verbose"} {
+		if field, ok := fields[key]; ok {
+			if value, ok := v.scalar(field, key); ok {
+				if value = strings.ToLower(value); value != "true" && value != "false" {
+					v.errorf(field.value, "%s must be true or false, got %q", key, field.value.Value)
+					continue
+				}
+				// Quoted booleans are accepted by Terrakube, decode them as such.
+				field.value.Tag, field.value.Value = "!!bool", value
+			}
+		}
+	}
+
+	_, hasImport := fields["importComands"]
+	if field, ok := fields["script"]; ok {
+		if script, ok := v.scalar(field, "script"); ok && strings.TrimSpace(script) == "" {
+			v.errorf(field.value, "script must not be empty")
+		}
+	} else if !hasImport {
+		v.errorf(node, "command must define a script")
+	}
+}
+
+type field struct {
+	key   *yaml.Node
+	value *yaml.Node
+}
+
+// mapping indexes the keys of a mapping node, reporting duplicated keys as
+// errors and unknown keys as warnings.
+func (v *validation) mapping(node *yaml.Node, kind string, known []string) map[string]field {
+	fields := map[string]field{}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if previous, duplicated := fields[key.Value]; duplicated {
+			v.errorf(key, "%s key %q is already defined at line %d", kind, key.Value, previous.key.Line)
+			continue
+		}
+		if !contains(known, key.Value) {
+			v.warnf(key, "unknown %s key %q", kind, key.Value)
+		}
+		fields[key.Value] = field{key: key, value: value}
+	}
+	return fields
+}
+
+func (v *validation) scalar(f field, name string) (string, bool) {
+	if f.value.Kind != yaml.ScalarNode {
+		v.errorf(f.value, "%s must be a single value", name)
+		return "", false
+	}
+	return f.value.Value, true
+}
+
+func (v *validation) wholeNumber(f field, name string, min int) (int, bool) {
+	value, ok := v.scalar(f, name)
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < min {
+		v.errorf(f.value, "%s must be a whole number of at least %d, got %q", name, min, value)
+		return 0, false
+	}
+	// Quoted numbers are accepted by Terrakube, decode them as such.
+	f.value.Tag, f.value.Value = "!!int", strconv.Itoa(n)
+	return n, true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,229 @@
+package tcl
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const validTemplate = `flow:
+  - type: "terraformApply"
+    name: "Apply"
+    step: 200
+  - type: "terraformPlan"
+    name: "Plan"
+    step: "100"
+    commands:
+      - runtime: "BASH"
+        priority: 100
+        after: "true"
+        script: |
+          echo "plan finished"
+  - type: "approval"
+    name: "Approve"
+    step: 150
+    team: "TERRAFORM_ADVANCED"
+`
+
+func TestParse_Valid(t *testing.T) {
+	template, problems := Parse(validTemplate)
+	if template == nil {
+		t.Fatalf("expected the template to parse, got %v", problems)
+	}
+	if len(problems) != 0 {
+		t.Errorf("expected no problems, got %v", problems)
+	}
+
+	steps := template.Steps()
+	if len(steps) != 3 || steps[0].Step != 100 || steps[1].Step != 150 || steps[2].Step != 200 {
+		t.Fatalf("expected the steps in execution order, got %+v", steps)
+	}
+	if steps[1].Team != "TERRAFORM_ADVANCED" {
+		t.Errorf("expected the approval team to be parsed, got %q", steps[1].Team)
+	}
+	command := steps[0].Commands[0]
+	if command.Runtime != "BASH" || command.Priority != 100 || !command.After || command.Script != "echo \"plan finished\"\n" {
+		t.Errorf("unexpected command %+v", command)
+	}
+}
+
+func TestParse_Problems(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		line    int
+		column  int
+		message string
+	}{
+		"syntax error": {
+			content: "flow:\n  - type: \"terraformPlan\"\n    step: 100: 2\n",
+			line:    3,
+			message: "mapping values are not allowed",
+		},
+		"empty": {
+			content: "",
+			message: "the template is empty",
+		},
+		"missing flow": {
+			content: "steps: []\n",
+			line:    1,
+			column:  1,
+			message: "must define a flow",
+		},
+		"unknown flow type": {
+			content: "flow:\n  - type: \"terraformPlans\"\n    step: 100\n",
+			line:    2,
+			column:  11,
+			message: `unknown flow type "terraformPlans"`,
+		},
+		"missing step": {
+			content: "flow:\n  - type: \"terraformPlan\"\n",
+			line:    2,
+			column:  5,
+			message: "missing the step number",
+		},
+		"negative step": {
+			content: "flow:\n  - type: \"terraformPlan\"\n    step: -1\n",
+			line:    3,
+			column:  11,
+			message: "step must be a whole number of at least 1",
+		},
+		"duplicated step": {
+			content: "flow:\n  - type: \"terraformPlan\"\n    step: 100\n  - type: \"terraformApply\"\n    step: 100\n",
+			line:    5,
+			column:  11,
+			message: "step 100 is already used by the flow item at line 3",
+		},
+		"approval without team": {
+			content: "flow:\n  - type: \"approval\"\n    step: 100\n",
+			line:    2,
+			column:  5,
+			message: "must define the team",
+		},
+		"unknown runtime": {
+			content: "flow:\n  - type: \"customScripts\"\n    step: 100\n    commands:\n      - runtime: \"PYTHON\"\n        priority: 1\n        script: \"echo\"\n",
+			line:    5,
+			column:  18,
+			message: `unknown runtime "PYTHON"`,
+		},
+		"command without script": {
+			content: "flow:\n  - type: \"customScripts\"\n    step: 100\n    commands:\n      - runtime: \"BASH\"\n        priority: 1\n",
+			line:    5,
+			column:  9,
+			message: "must define a script",
+		},
+		"custom scripts without commands": {
+			content: "flow:\n  - type: \"customScripts\"\n    step: 100\n",
+			line:    2,
+			column:  5,
+			message: "at least one command",
+		},
+		"invalid boolean": {
+			content: "flow:\n  - type: \"customScripts\"\n    step: 100\n    commands:\n      - runtime: \"BASH\"\n        priority: 1\n        before: \"yes please\"\n        script: \"echo\"\n",
+			line:    7,
+			column:  17,
+			message: "before must be true or false",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			template, problems := Parse(tt.content)
+			if template != nil {
+				t.Fatalf("expected no template for invalid content, got %+v", template)
+			}
+			if len(problems) == 0 {
+				t.Fatal("expected at least one problem")
+			}
+
+			var problem Problem
+			for _, problem = range problems {
+				if !problem.Warning {
+					break
+				}
+			}
+			if problem.Warning {
+				t.Fatalf("expected an error, got only warnings: %v", problems)
+			}
+			if problem.Line != tt.line || problem.Column != tt.column {
+				t.Errorf("expected the problem at line %d, column %d, got %s", tt.line, tt.column, problem)
+			}
+			if !strings.Contains(problem.Message, tt.message) {
+				t.Errorf("expected the message to contain %q, got %q", tt.message, problem.Message)
+			}
+		})
+	}
+}
+
+func TestParse_UnknownKeysAreWarnings(t *testing.T) {
+	template, problems := Parse("flow:\n  - type: \"terraformPlan\"\n    step: 100\n    stepName: \"typo\"\n")
+	if template == nil {
+		t.Fatalf("expected unknown keys not to fail the template, got %v", problems)
+	}
+	if len(problems) != 1 || !problems[0].Warning || problems[0].Line != 4 {
+		t.Errorf("expected one warning at line 4, got %v", problems)
+	}
+}
+
+func TestProblem_Error(t *testing.T) {
+	if got := (Problem{Line: 3, Column: 5, Message: "boom"}).Error(); got != "line 3, column 5: boom" {
+		t.Errorf("unexpected message %q", got)
+	}
+	if got := (Problem{Line: 3, Message: "boom"}).Error(); got != "line 3: boom" {
+		t.Errorf("unexpected message %q", got)
+	}
+}
+
+func TestRender_RoundTrips(t *testing.T) {
+	template := &Template{Flow: []Flow{
+		{Type: "terraformPlan", Name: "Plan", Step: 100, Commands: []Command{
+			{Runtime: "BASH", Priority: 100, After: true, Script: "echo \"plan finished\"\nexit 0\n"},
+		}},
+		{Type: "approval", Step: 150, Team: "OPS"},
+	}}
+
+	content, err := Render(template)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := `flow:
+  - type: terraformPlan
+    name: Plan
+    step: 100
+    commands:
+      - runtime: BASH
+        priority: 100
+        after: true
+        script: |
+          echo "plan finished"
+          exit 0
+  - type: approval
+    step: 150
+    team: OPS
+`
+	if content != want {
+		t.Errorf("unexpected rendering:\n%s", content)
+	}
+
+	parsed, problems := Parse(content)
+	if parsed == nil || len(problems) != 0 {
+		t.Fatalf("expected the rendered template to be valid, got %v", problems)
+	}
+	if !reflect.DeepEqual(parsed, template) {
+		t.Errorf("expected the rendered template to parse back to the same flow, got %+v", parsed)
+	}
+}
+
+func TestEquivalent(t *testing.T) {
+	a := "flow:\n  - type: \"terraformPlan\"\n    step: 100\n    name: Plan\n"
+	b := "# reformatted\nflow:\n- name: 'Plan'\n  step: 100\n  type: terraformPlan\n"
+
+	if !Equivalent(a, b) {
+		t.Error("expected quoting, indentation, comments and key order not to matter")
+	}
+	if Equivalent(a, strings.Replace(a, "100", "200", 1)) {
+		t.Error("expected a different step to matter")
+	}
+	if Equivalent("flow: [", "flow:  [") {
+		t.Error("expected unparsable documents to only be equivalent when identical")
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/terrakube-io/terraform-provider-terrakube/notify
+
+go 1.21
@@ -0,0 +1,148 @@
+// Package notify verifies and parses the WEBHOOK notifications Terrakube
+// sends for a notification configuration with a signing secret.
+//
+// It is a module of its own that only depends on the standard library, so
+// receivers can import it without pulling in the provider:
+//
+//	go get github.com/terrakube-io/terraform-provider-terrakube/notify
+//
+//	http.Handle("/terrakube", notify.Handler(secret, func(w http.ResponseWriter, r *http.Request, payload *notify.Payload) {
+//		log.Printf("job %d of %s is %s", payload.JobID, payload.Workspace, payload.JobStatus)
+//	}))
+package notify
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SignatureHeader is the header Terrakube sends the signature of the body in.
+const SignatureHeader = "X-Terrakube-Signature"
+
+// signaturePrefix is the optional algorithm prefix of a signature.
+const signaturePrefix = "sha256="
+
+// maxBodySize limits the body Handler reads, notifications are a few KB.
+const maxBodySize = 1 << 20
+
+var (
+	// ErrMissingSignature is returned when a request has no signature.
+	ErrMissingSignature = errors.New("notify: missing " + SignatureHeader + " header")
+	// ErrInvalidSignature is returned when the signature doesn't match the body.
+	ErrInvalidSignature = errors.New("notify: invalid signature")
+	// ErrMissingSecret is returned when the secret to verify with is empty,
+	// anyone can sign a body with an empty secret.
+	ErrMissingSecret = errors.New("notify: missing signing secret")
+)
+
+// Payload is the body of a WEBHOOK notification.
+type Payload struct {
+	OrganizationID string    `json:"organizationId"`
+	Organization   string    `json:"organization"`
+	WorkspaceID    string    `json:"workspaceId"`
+	Workspace      string    `json:"workspace"`
+	JobID          int       `json:"jobId"`
+	JobStatus      string    `json:"jobStatus"`
+	CommitID       string    `json:"commitId"`
+	URL            string    `json:"url"`
+	TriggeredBy    string    `json:"triggeredBy"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+// NewSecret returns a random signing secret, 32 bytes encoded as hex.
+func NewSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("notify: generating secret: %w", err)
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// Sign returns the signature of body, the HMAC-SHA256 of it keyed with
+// secret, in the format of SignatureHeader.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks signature against body in constant time. The sha256= prefix
+// is optional. An empty secret never verifies.
+func Verify(secret string, body []byte, signature string) error {
+	if secret == "" {
+		return ErrMissingSecret
+	}
+	if signature == "" {
+		return ErrMissingSignature
+	}
+
+	got, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(signature), signaturePrefix))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Parse decodes a notification body.
+func Parse(body []byte) (*Payload, error) {
+	var payload Payload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("notify: parsing payload: %w", err)
+	}
+	if payload.JobStatus == "" {
+		return nil, errors.New("notify: parsing payload: missing jobStatus")
+	}
+	return &payload, nil
+}
+
+// VerifyRequest reads the body of r, checks its signature and parses it.
+func VerifyRequest(secret string, r *http.Request) (*Payload, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("notify: reading body: %w", err)
+	}
+	if err := Verify(secret, body, r.Header.Get(SignatureHeader)); err != nil {
+		return nil, err
+	}
+	return Parse(body)
+}
+
+// Handler calls next with the payload of every signed notification, it
+// responds 401 to requests with a missing or invalid signature, 400 to
+// requests that aren't notifications and 500 to every request when secret is
+// empty.
+func Handler(secret string, next func(w http.ResponseWriter, r *http.Request, payload *Payload)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		payload, err := VerifyRequest(secret, r)
+		switch {
+		case errors.Is(err, ErrMissingSecret):
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		case errors.Is(err, ErrMissingSignature), errors.Is(err, ErrInvalidSignature):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			next(w, r, payload)
+		}
+	})
+}
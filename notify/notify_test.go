@@ -0,0 +1,120 @@
+package notify
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testBody = `{"organizationId":"org-1","organization":"simple","workspaceId":"ws-1","workspace":"networking",` +
+	`"jobId":42,"jobStatus":"failed","commitId":"abc123","url":"https://terrakube.example.com/jobs/42",` +
+	`"triggeredBy":"jane","timestamp":"2026-01-02T03:04:05Z"}`
+
+func TestVerify(t *testing.T) {
+	signature := Sign("secret", []byte(testBody))
+	if !strings.HasPrefix(signature, "sha256=") {
+		t.Fatalf("unexpected signature format %q", signature)
+	}
+
+	tests := []struct {
+		name      string
+		secret    string
+		body      string
+		signature string
+		want      error
+	}{
+		{name: "valid", secret: "secret", body: testBody, signature: signature},
+		{name: "without prefix", secret: "secret", body: testBody, signature: strings.TrimPrefix(signature, "sha256=")},
+		{name: "missing", secret: "secret", body: testBody, want: ErrMissingSignature},
+		{name: "other secret", secret: "other", body: testBody, signature: signature, want: ErrInvalidSignature},
+		{name: "tampered body", secret: "secret", body: strings.Replace(testBody, "failed", "completed", 1), signature: signature, want: ErrInvalidSignature},
+		{name: "not hex", secret: "secret", body: testBody, signature: "sha256=zz", want: ErrInvalidSignature},
+		{name: "empty secret", body: testBody, signature: Sign("", []byte(testBody)), want: ErrMissingSecret},
+	}
+
+	for _, test := range tests {
+		if err := Verify(test.secret, []byte(test.body), test.signature); !errors.Is(err, test.want) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, err)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	payload, err := Parse([]byte(testBody))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if payload.JobID != 42 || payload.JobStatus != "failed" || payload.Workspace != "networking" || payload.Timestamp.Year() != 2026 {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+
+	if _, err := Parse([]byte(`{"jobId":42}`)); err == nil {
+		t.Error("expected an error for a payload without jobStatus")
+	}
+}
+
+func TestNewSecret(t *testing.T) {
+	first, err := NewSecret()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	second, _ := NewSecret()
+	if len(first) != 64 || first == second {
+		t.Errorf("expected distinct 64 character secrets, got %q and %q", first, second)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	var received *Payload
+	server := httptest.NewServer(Handler("secret", func(w http.ResponseWriter, _ *http.Request, payload *Payload) {
+		received = payload
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	send := func(body, signature string) int {
+		request, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(body))
+		if signature != "" {
+			request.Header.Set(SignatureHeader, signature)
+		}
+		response, err := server.Client().Do(request)
+		if err != nil {
+			t.Fatalf("sending notification: %s", err)
+		}
+		response.Body.Close()
+		return response.StatusCode
+	}
+
+	if status := send(testBody, Sign("secret", []byte(testBody))); status != http.StatusNoContent || received == nil || received.JobID != 42 {
+		t.Errorf("expected a signed notification to be delivered, got status %d and payload %+v", status, received)
+	}
+	if status := send(testBody, ""); status != http.StatusUnauthorized {
+		t.Errorf("expected 401 for an unsigned notification, got %d", status)
+	}
+	if status := send(testBody, Sign("other", []byte(testBody))); status != http.StatusUnauthorized {
+		t.Errorf("expected 401 for a notification signed with another secret, got %d", status)
+	}
+	if status := send(`{}`, Sign("secret", []byte(`{}`))); status != http.StatusBadRequest {
+		t.Errorf("expected 400 for a signed body that isn't a notification, got %d", status)
+	}
+}
+
+func TestHandler_EmptySecret(t *testing.T) {
+	server := httptest.NewServer(Handler("", func(w http.ResponseWriter, _ *http.Request, _ *Payload) {
+		t.Errorf("expected no notification to be delivered without a secret")
+	}))
+	defer server.Close()
+
+	request, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(testBody))
+	request.Header.Set(SignatureHeader, Sign("", []byte(testBody)))
+	response, err := server.Client().Do(request)
+	if err != nil {
+		t.Fatalf("sending notification: %s", err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusInternalServerError {
+		t.Errorf("expected 500 without a secret, got %d", response.StatusCode)
+	}
+}